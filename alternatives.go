@@ -0,0 +1,187 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// Alternative is one of several distinct solutions of a model.
+type Alternative struct {
+	// Solution is the solution.
+	Solution Solution
+	// Differences are the binaries whose value differs from their value in
+	// the best alternative. They are empty for the best alternative.
+	Differences mip.Vars
+}
+
+// AlternativesSolver finds up to a number of distinct solutions of a model
+// with binary variables, for example to present planners with several good
+// alternatives. Solutions are distinct if they differ in the value of at
+// least one binary; variables that are not binary are not compared.
+//
+// HiGHS has no solution pool. The solver solves the model on a single HiGHS
+// instance and, after every solve, adds a no-good cut that excludes the
+// values of the binaries of the solution found, so the next solve finds the
+// next best solution. With a MIP gap of 0 the alternatives are the best
+// solutions of the model.
+type AlternativesSolver struct {
+	model             mip.Model
+	options           Options
+	count             int
+	absoluteTolerance float64
+	relativeTolerance float64
+}
+
+// NewAlternativesSolver creates a solver that finds up to count distinct
+// solutions of the model, with the typed options applied to every solve.
+func NewAlternativesSolver(model mip.Model, options Options, count int) *AlternativesSolver {
+	return &AlternativesSolver{
+		model:             model,
+		options:           options,
+		count:             count,
+		absoluteTolerance: math.Inf(1),
+		relativeTolerance: math.Inf(1),
+	}
+}
+
+// SetTolerance restricts the alternatives to solutions whose objective value
+// is within max(absolute, relative·|z|) of the optimal value z. By default
+// alternatives are not restricted.
+func (s *AlternativesSolver) SetTolerance(absolute, relative float64) {
+	s.absoluteTolerance = absolute
+	s.relativeTolerance = relative
+}
+
+// Solve finds the alternatives and returns them ranked by objective value,
+// best first. It stops when count alternatives are found, no further
+// solution exists within the tolerance or the duration of the options is
+// used up. It returns no alternatives if the model is not solved to
+// optimality, except for constraints without terms that make the model
+// infeasible: the only alternative is then the infeasible solution that
+// reports them.
+func (s *AlternativesSolver) Solve(options mip.SolveOptions) ([]Alternative, error) {
+	start := time.Now()
+	alternatives, err := newInstance(s.model, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		return []Alternative{{Solution: violated, Differences: make(mip.Vars, 0)}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer alternatives.close()
+
+	semi := semiVars(s.model)
+	binaries := make(mip.Vars, 0)
+	for _, v := range s.model.Vars() {
+		if isBinary(v, semi) {
+			binaries = append(binaries, v)
+		}
+	}
+	if len(binaries) == 0 {
+		return nil, errors.New("highs: alternatives need a model with binary variables")
+	}
+
+	if err := alternatives.pass(); err != nil {
+		return nil, err
+	}
+
+	maximize := s.model.Objective().IsMaximize()
+	deadline := start.Add(options.Duration)
+	solutions := make([]*highsSolution, 0, s.count)
+	for len(solutions) < s.count {
+		hasTime, err := alternatives.setDeadline(deadline)
+		if err != nil {
+			return nil, err
+		}
+		if !hasTime {
+			break
+		}
+
+		solution, err := alternatives.run()
+		if err != nil {
+			return nil, err
+		}
+		if !solution.IsOptimal() {
+			break
+		}
+		solution.trim(alternatives.input)
+		solution.runtime = time.Since(start)
+		solutions = append(solutions, solution)
+
+		rows := []highsRow{noGoodCut(binaries, solution)}
+		if len(solutions) == 1 {
+			if row, ok := s.objectiveBound(solution.objectiveValue, maximize); ok {
+				rows = append(rows, row)
+			}
+		}
+		if _, err := alternatives.addRows(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	sign := 1.0
+	if maximize {
+		sign = -1.0
+	}
+	sort.SliceStable(solutions, func(i, j int) bool {
+		return sign*solutions[i].objectiveValue < sign*solutions[j].objectiveValue
+	})
+
+	result := make([]Alternative, len(solutions))
+	for k, solution := range solutions {
+		result[k] = Alternative{Solution: solution, Differences: make(mip.Vars, 0)}
+		for _, b := range binaries {
+			if math.Round(solution.Value(b)) != math.Round(solutions[0].Value(b)) {
+				result[k].Differences = append(result[k].Differences, b)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// objectiveBound returns the row keeping the objective within the tolerance
+// of the optimal value z, and false if the tolerance is infinite.
+func (s *AlternativesSolver) objectiveBound(z float64, maximize bool) (highsRow, bool) {
+	tolerance := math.Max(s.absoluteTolerance, s.relativeTolerance*math.Abs(z))
+	if math.IsInf(tolerance, 1) || math.IsNaN(tolerance) {
+		return highsRow{}, false
+	}
+
+	// The row holds the terms of the objective, without its constant.
+	z -= objectiveConstant(s.model)
+	row := highsRow{
+		terms: linearTerms(s.model.Objective().Terms()),
+		lower: math.Inf(-1),
+		upper: z + tolerance,
+	}
+	if maximize {
+		row.lower, row.upper = z-tolerance, math.Inf(1)
+	}
+
+	return row, true
+}
+
+// noGoodCut returns the row excluding the values of the binaries in the
+// solution:
+//
+//	Σ_{x_i = 0} x_i + Σ_{x_i = 1} (1 - x_i) >= 1
+func noGoodCut(binaries mip.Vars, solution mip.Solution) highsRow {
+	row := highsRow{lower: 1, upper: math.Inf(1)}
+	for _, b := range binaries {
+		coefficient := 1.0
+		if solution.Value(b) >= 0.5 {
+			coefficient = -1.0
+			row.lower--
+		}
+		row.terms = append(row.terms, linearTerm{index: b.Index(), coefficient: coefficient})
+	}
+
+	return row
+}
@@ -0,0 +1,96 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// pickTwo returns a model picking two of three items with values 5, 4 and 2,
+// maximizing the value.
+func pickTwo() (mip.Model, mip.Vars) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	pick := model.NewConstraint(mip.Equal, 2)
+	items := make(mip.Vars, 0)
+	for _, value := range []float64{5, 4, 2} {
+		x := model.NewBool()
+		model.Objective().NewTerm(value, x)
+		pick.NewTerm(1, x)
+		items = append(items, x)
+	}
+
+	return model, items
+}
+
+func TestAlternatives(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		tolerance float64
+		want      []float64
+	}{
+		{name: "all", count: 5, tolerance: math.Inf(1), want: []float64{9, 7, 6}},
+		{name: "count", count: 2, tolerance: math.Inf(1), want: []float64{9, 7}},
+		{name: "tolerance", count: 5, tolerance: 2, want: []float64{9, 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model, items := pickTwo()
+			solver := highs.NewAlternativesSolver(model, highs.DefaultOptions(), test.count)
+			solver.SetTolerance(test.tolerance, 0)
+
+			alternatives, err := solver.Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(alternatives) != len(test.want) {
+				t.Fatalf("got %d alternatives, want %d", len(alternatives), len(test.want))
+			}
+
+			for k, alternative := range alternatives {
+				got := alternative.Solution.ObjectiveValue()
+				if math.Abs(got-test.want[k]) > 1e-6 {
+					t.Errorf("alternative %d: got objective %v, want %v", k, got, test.want[k])
+				}
+
+				// Picking two of three items differs from the best pick in
+				// two items.
+				wantDifferences := 2
+				if k == 0 {
+					wantDifferences = 0
+				}
+				if len(alternative.Differences) != wantDifferences {
+					t.Errorf("alternative %d: got %d differences, want %d",
+						k, len(alternative.Differences), wantDifferences)
+				}
+				for _, v := range alternative.Differences {
+					if alternative.Solution.Value(v) == alternatives[0].Solution.Value(v) {
+						t.Errorf("alternative %d: var %d does not differ", k, v.Index())
+					}
+				}
+			}
+
+			if alternatives[0].Solution.Value(items[2]) != 0 {
+				t.Error("expected best alternative to leave out the last item")
+			}
+		})
+	}
+}
+
+func TestAlternativesWithoutBinaries(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	model.Objective().NewTerm(1, x)
+
+	solver := highs.NewAlternativesSolver(model, highs.DefaultOptions(), 3)
+	if _, err := solver.Solve(defaultOptions()); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,533 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// rayTolerance is the magnitude below which entries of a dual ray and of the
+// combinations of columns it induces are treated as 0.
+const rayTolerance = 1e-9
+
+// BendersSubproblem is an LP subproblem of a [BendersSolver]: a model whose
+// linking variables take the values of variables of the master. Its
+// objective is the cost of the master solution it evaluates.
+type BendersSubproblem struct {
+	model      mip.Model
+	links      []bendersLink
+	lowerBound float64
+}
+
+// bendersLink links a variable of a subproblem to a variable of the master.
+type bendersLink struct {
+	master     mip.Var
+	subproblem mip.Var
+}
+
+// NewBendersSubproblem creates a subproblem for the LP model, with a lower
+// bound of 0 on its objective value.
+func NewBendersSubproblem(model mip.Model) *BendersSubproblem {
+	return &BendersSubproblem{
+		model: model,
+		links: make([]bendersLink, 0),
+	}
+}
+
+// Link declares that the variable of the subproblem takes the value of the
+// variable of the master. The bounds of the variable of the subproblem are
+// replaced by the value of the master variable.
+func (s *BendersSubproblem) Link(master, subproblem mip.Var) {
+	s.links = append(s.links, bendersLink{master: master, subproblem: subproblem})
+}
+
+// SetLowerBound sets a lower bound on the objective value of the subproblem
+// over all master solutions, such as the default 0 for subproblems with
+// non-negative costs. The master is unbounded without one.
+func (s *BendersSubproblem) SetLowerBound(bound float64) {
+	s.lowerBound = bound
+}
+
+// Model returns the model of the subproblem.
+func (s *BendersSubproblem) Model() mip.Model {
+	return s.model
+}
+
+// BendersResult is the result of a [BendersSolver].
+type BendersResult struct {
+	// Master is the best master solution found. Its objective value is the
+	// upper bound, the cost of the master and of all subproblems. It is not
+	// optimal if no master solution feasible for all subproblems was found.
+	Master Solution
+	// Subproblems are the solutions of the subproblems for the master
+	// solution, in the order of the subproblems of the solver.
+	Subproblems []Solution
+	// LowerBound is the best lower bound on the optimal value, -Inf if
+	// none was found.
+	LowerBound float64
+	// UpperBound is the cost of the best master solution, +Inf if none was
+	// found.
+	UpperBound float64
+	// Gap is the gap between the bounds relative to the upper bound.
+	Gap float64
+	// Iterations is the number of times the master was solved.
+	Iterations int
+	// OptimalityCuts is the number of optimality cuts added to the master.
+	OptimalityCuts int
+	// FeasibilityCuts is the number of feasibility cuts added to the master.
+	FeasibilityCuts int
+}
+
+// BendersSolver solves a two-stage model by Benders decomposition. The
+// master MIP holds the first-stage decisions; for a master solution, the LP
+// subproblems evaluate the cost of the second stage. The master and the
+// subproblems minimize.
+//
+// The master gets one variable per subproblem estimating its objective
+// value. After every solve of the master, the subproblems are solved with
+// their linking variables fixed to the values of the master. An optimal
+// subproblem whose value exceeds its estimate yields an optimality cut from
+// the reduced costs of the linking variables, an infeasible subproblem a
+// feasibility cut from the dual ray HiGHS reports. The cuts are added to
+// the master like lazy constraints until no cut is found or the bounds
+// meet within the MIP gap of the options.
+type BendersSolver struct {
+	master      mip.Model
+	options     Options
+	subproblems []*BendersSubproblem
+	parallelism int
+}
+
+// NewBendersSolver creates a solver for the master model and subproblems,
+// with the typed options applied to every solve. By default it solves the
+// CPUs divided by the threads of the options subproblems in parallel, or
+// one subproblem at a time if the options leave the number of threads to
+// HiGHS.
+func NewBendersSolver(
+	master mip.Model,
+	options Options,
+	subproblems ...*BendersSubproblem,
+) *BendersSolver {
+	return &BendersSolver{
+		master:      master,
+		options:     options,
+		subproblems: append([]*BendersSubproblem(nil), subproblems...),
+		parallelism: defaultParallelism(options),
+	}
+}
+
+// SetParallelism sets the number of subproblems solved in parallel, each
+// with its own HiGHS instance.
+func (s *BendersSolver) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
+// bendersInstance is the HiGHS instance of a subproblem.
+type bendersInstance struct {
+	*instance
+	// theta is the column of the master estimating the objective value.
+	theta int
+	// columns are the columns of the linking variables of the subproblem
+	// and masters the columns of the master variables they link to.
+	columns []int
+	masters []int
+	linked  map[int]bool
+	// tolerance is the primal feasibility tolerance of the typed options.
+	tolerance float64
+}
+
+// bendersCut is the cut found by a subproblem for a master solution.
+type bendersCut struct {
+	row         highsRow
+	found       bool
+	feasibility bool
+}
+
+// Solve solves the master and subproblems until no cut is found, the gap
+// between the bounds is within the MIP gap of the options or the duration
+// of the options is used up. Subproblems are solved without presolve, so
+// HiGHS reports dual rays of infeasible ones.
+func (s *BendersSolver) Solve(options mip.SolveOptions) (BendersResult, error) {
+	start := time.Now()
+	result := BendersResult{
+		Master:      &highsSolution{solutionStatus: timeLimit},
+		Subproblems: make([]Solution, len(s.subproblems)),
+		LowerBound:  math.Inf(-1),
+		UpperBound:  math.Inf(1),
+		Gap:         math.Inf(1),
+	}
+	if len(s.subproblems) == 0 {
+		return result, errors.New("highs: Benders decomposition needs at least one subproblem")
+	}
+	if s.master.Objective().IsMaximize() {
+		return result, errors.New("highs: Benders decomposition needs a master that minimizes")
+	}
+
+	master, err := newInstance(s.master, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		result.Master = violated
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	defer master.close()
+
+	if err := master.pass(); err != nil {
+		return result, err
+	}
+
+	instances := make([]*bendersInstance, len(s.subproblems))
+	defer func() {
+		for _, sub := range instances {
+			if sub != nil {
+				sub.close()
+			}
+		}
+	}()
+	thetas := make([]highsColumn, len(s.subproblems))
+	for k, subproblem := range s.subproblems {
+		sub, err := s.newSubproblem(k, subproblem, options, start)
+		// A subproblem with violated constraints without terms is
+		// infeasible for every master solution.
+		if violated := violatedSolution(err); violated != nil {
+			result.Subproblems[k] = violated
+			result.Master = &highsSolution{
+				runtime:        time.Since(start),
+				solutionStatus: infeasible,
+			}
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		sub.theta = master.input.numColumns + k
+		instances[k] = sub
+		thetas[k] = highsColumn{cost: 1, lower: subproblem.lowerBound, upper: math.Inf(1)}
+	}
+	if _, err := master.addColumns(thetas); err != nil {
+		return result, err
+	}
+
+	deadline := start.Add(options.Duration)
+	for {
+		hasTime, err := master.setDeadline(deadline)
+		if err != nil {
+			return result, err
+		}
+		if !hasTime {
+			return result, nil
+		}
+
+		solution, err := master.run()
+		if err != nil {
+			return result, err
+		}
+		result.Iterations++
+		if !solution.IsOptimal() {
+			if math.IsInf(result.UpperBound, 1) {
+				solution.trim(master.input)
+				solution.runtime = time.Since(start)
+				result.Master = solution
+			}
+			return result, nil
+		}
+
+		bound, err := master.bound(solution)
+		if err != nil {
+			return result, err
+		}
+		result.LowerBound = math.Max(result.LowerBound, bound)
+		result.Gap = s.gap(result)
+
+		subSolutions := make([]*highsSolution, len(instances))
+		cuts := make([]bendersCut, len(instances))
+		err = forEachParallel(len(instances), s.parallelism, func(k int) error {
+			var err error
+			subSolutions[k], cuts[k], err = instances[k].evaluate(solution.values, deadline, start)
+			return err
+		})
+		if err != nil {
+			return result, err
+		}
+
+		feasible, timedOut := true, false
+		cost := solution.objectiveValue
+		rows := make([]highsRow, 0)
+		for k, sub := range instances {
+			subSolution := subSolutions[k]
+			switch {
+			case subSolution.IsOptimal():
+				cost += subSolution.objectiveValue - solution.values[sub.theta]
+			case subSolution.solutionStatus == timeLimit:
+				timedOut = true
+			default:
+				feasible = false
+			}
+			if !cuts[k].found {
+				continue
+			}
+			rows = append(rows, cuts[k].row)
+			if cuts[k].feasibility {
+				result.FeasibilityCuts++
+			} else {
+				result.OptimalityCuts++
+			}
+		}
+
+		if feasible && !timedOut && cost < result.UpperBound {
+			solution.trim(master.input)
+			solution.objectiveValue = cost
+			solution.runtime = time.Since(start)
+			result.Master = solution
+			for k, subSolution := range subSolutions {
+				result.Subproblems[k] = subSolution
+			}
+			result.UpperBound = cost
+			result.Gap = s.gap(result)
+		}
+
+		if timedOut {
+			return result, nil
+		}
+		if len(rows) == 0 || gapClosed(result.UpperBound, result.LowerBound, options) {
+			return result, nil
+		}
+		if _, err := master.addRows(rows); err != nil {
+			return result, err
+		}
+	}
+}
+
+// gap returns the gap between the bounds of the result relative to the
+// upper bound.
+func (s *BendersSolver) gap(result BendersResult) float64 {
+	if math.IsInf(result.UpperBound, 1) || math.IsInf(result.LowerBound, -1) {
+		return math.Inf(1)
+	}
+
+	return (result.UpperBound - result.LowerBound) / math.Max(math.Abs(result.UpperBound), 1e-10)
+}
+
+// newSubproblem creates the HiGHS instance of the k-th subproblem.
+func (s *BendersSolver) newSubproblem(
+	k int,
+	subproblem *BendersSubproblem,
+	options mip.SolveOptions,
+	start time.Time,
+) (*bendersInstance, error) {
+	if subproblem.model.Objective().IsMaximize() {
+		return nil, fmt.Errorf("highs: Benders subproblem %d must minimize", k)
+	}
+
+	sub, err := newInstance(subproblem.model, s.options, options, start, false)
+	if err != nil {
+		return nil, fmt.Errorf("highs: Benders subproblem %d: %w", k, err)
+	}
+	if sub.input.isIntegerProblem || sub.input.isQuadraticProblem {
+		sub.close()
+		return nil, fmt.Errorf("highs: Benders subproblem %d must be an LP", k)
+	}
+	if err := sub.pass(); err != nil {
+		sub.close()
+		return nil, err
+	}
+	if err := setStringOption(sub.ptr, "presolve", "off"); err != nil {
+		sub.close()
+		return nil, err
+	}
+
+	instance := &bendersInstance{
+		instance:  sub,
+		columns:   make([]int, len(subproblem.links)),
+		masters:   make([]int, len(subproblem.links)),
+		linked:    make(map[int]bool, len(subproblem.links)),
+		tolerance: s.options.primalFeasibilityTolerance(),
+	}
+	for l, link := range subproblem.links {
+		instance.columns[l] = link.subproblem.Index()
+		instance.masters[l] = link.master.Index()
+		instance.linked[link.subproblem.Index()] = true
+	}
+
+	return instance, nil
+}
+
+// evaluate solves the subproblem for the values of the master columns and
+// returns its solution and the cut it finds, if any.
+func (b *bendersInstance) evaluate(
+	values []float64,
+	deadline time.Time,
+	start time.Time,
+) (*highsSolution, bendersCut, error) {
+	fixed := make([]float64, len(b.columns))
+	for l, column := range b.masters {
+		fixed[l] = values[column]
+	}
+	if err := b.changeColumnBounds(b.columns, fixed, fixed); err != nil {
+		return nil, bendersCut{}, err
+	}
+
+	hasTime, err := b.setDeadline(deadline)
+	if err != nil {
+		return nil, bendersCut{}, err
+	}
+	if !hasTime {
+		return &highsSolution{solutionStatus: timeLimit}, bendersCut{}, nil
+	}
+
+	solution, err := b.run()
+	if err != nil {
+		return nil, bendersCut{}, err
+	}
+	solution.trim(b.input)
+	solution.runtime = time.Since(start)
+
+	switch solution.solutionStatus {
+	case optimal:
+		return solution, b.optimalityCut(solution, values), nil
+	case infeasible, unboundedOrInfeasible:
+		cut, err := b.feasibilityCut(values)
+		return solution, cut, err
+	case unbounded:
+		return nil, bendersCut{}, errors.New("highs: Benders subproblem is unbounded")
+	default:
+		return solution, bendersCut{}, nil
+	}
+}
+
+// optimalityCut returns the cut θ >= φ + Σ r_l (x_l - x̄_l), where φ is the
+// value of the subproblem for the master values x̄ and r the reduced costs
+// of the linking columns, if the estimate θ of the master is below φ.
+func (b *bendersInstance) optimalityCut(solution *highsSolution, values []float64) bendersCut {
+	phi := solution.objectiveValue
+	tolerance := b.tolerance * math.Max(1, math.Abs(phi))
+	if values[b.theta] >= phi-tolerance {
+		return bendersCut{}
+	}
+
+	coefficients := map[int]float64{b.theta: 1}
+	lower := phi
+	for l, column := range b.columns {
+		r := solution.reducedCosts[column]
+		coefficients[b.masters[l]] -= r
+		lower -= r * values[b.masters[l]]
+	}
+
+	return bendersCut{
+		row:   highsRow{terms: cutTerms(coefficients), lower: lower, upper: math.Inf(1)},
+		found: true,
+	}
+}
+
+// feasibilityCut returns the cut excluding the master values from a dual
+// ray λ of the rows L <= Ay <= U of the subproblem. Every feasible y
+// satisfies
+//
+//	Σ_j min(g_j l_j, g_j u_j) <= λ·Ay <= Σ_i max(λ_i L_i, λ_i U_i), g = Aᵀλ,
+//
+// where the linking columns are fixed to the master values x, so x must
+// satisfy Σ_l g_l x_l <= h with h the terms that do not depend on x. The
+// ray certifies that x̄ violates it; as HiGHS does not document the sign of
+// the ray, both λ and -λ are tried.
+func (b *bendersInstance) feasibilityCut(values []float64) (bendersCut, error) {
+	ray, ok, err := b.dualRay()
+	if err != nil {
+		return bendersCut{}, err
+	}
+	if !ok {
+		return bendersCut{}, errors.New("highs: Benders subproblem is infeasible without a dual ray")
+	}
+
+	for _, sign := range []float64{1, -1} {
+		if cut, ok := b.farkasCut(ray, sign, values); ok {
+			return cut, nil
+		}
+	}
+
+	return bendersCut{}, errors.New("highs: dual ray of Benders subproblem does not yield a cut")
+}
+
+// farkasCut returns the cut of the dual ray sign·ray, and false if it is
+// not a certificate of the infeasibility of the master values.
+func (b *bendersInstance) farkasCut(ray []float64, sign float64, values []float64) (bendersCut, bool) {
+	input := b.input
+	g := make([]float64, input.numColumns)
+	h := 0.0
+	for i := 0; i < input.numRows; i++ {
+		lambda := sign * ray[i]
+		if math.Abs(lambda) <= rayTolerance {
+			continue
+		}
+
+		bound := float64(input.rowUpperBound[i])
+		if lambda < 0 {
+			bound = float64(input.rowLowerBound[i])
+		}
+		if isInfinite(bound) {
+			return bendersCut{}, false
+		}
+		h += lambda * bound
+
+		end := input.numNonZeros
+		if i+1 < input.numRows {
+			end = int(input.rowConstraintMatrixBegins[i+1])
+		}
+		for k := int(input.rowConstraintMatrixBegins[i]); k < end; k++ {
+			column := int(input.rowConstraintMatrixIndices[k])
+			g[column] += lambda * float64(input.rowConstraintMatrixValues[k])
+		}
+	}
+
+	for j, coefficient := range g {
+		if b.linked[j] || math.Abs(coefficient) <= rayTolerance {
+			continue
+		}
+
+		bound := float64(input.columnLowerBound[j])
+		if coefficient < 0 {
+			bound = float64(input.columnUpperBound[j])
+		}
+		if isInfinite(bound) {
+			return bendersCut{}, false
+		}
+		h -= coefficient * bound
+	}
+
+	coefficients := make(map[int]float64)
+	activity := 0.0
+	for l, column := range b.columns {
+		coefficients[b.masters[l]] += g[column]
+		activity += g[column] * values[b.masters[l]]
+	}
+	if activity <= h+rayTolerance*math.Max(1, math.Abs(h)) {
+		return bendersCut{}, false
+	}
+
+	return bendersCut{
+		row:         highsRow{terms: cutTerms(coefficients), lower: math.Inf(-1), upper: h},
+		found:       true,
+		feasibility: true,
+	}, true
+}
+
+// cutTerms returns the non-zero coefficients as terms sorted by column.
+func cutTerms(coefficients map[int]float64) []linearTerm {
+	terms := make([]linearTerm, 0, len(coefficients))
+	for index, coefficient := range coefficients {
+		if coefficient != 0 {
+			terms = append(terms, linearTerm{index: index, coefficient: coefficient})
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i].index < terms[j].index
+	})
+
+	return terms
+}
@@ -0,0 +1,169 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// facilityLocation is a two-stage facility location: facilities are opened
+// first, then the demands of the customers of equally likely scenarios are
+// shipped from the open facilities.
+type facilityLocation struct {
+	fixedCosts []float64
+	capacities []float64
+	// costs[i][j] is the cost of shipping a unit from i to customer j.
+	costs [][]float64
+	// demands[s][j] is the demand of customer j in scenario s.
+	demands [][]float64
+}
+
+var facilities = facilityLocation{
+	fixedCosts: []float64{10, 12, 8},
+	capacities: []float64{30, 25, 20},
+	costs: [][]float64{
+		{2, 4, 5, 3},
+		{3, 1, 4, 6},
+		{5, 3, 2, 2},
+	},
+	demands: [][]float64{
+		{10, 15, 8, 12},
+		{14, 9, 12, 6},
+	},
+}
+
+// open adds the binaries opening the facilities to the model.
+func (f facilityLocation) open(model mip.Model) mip.Vars {
+	open := make(mip.Vars, len(f.fixedCosts))
+	for i, cost := range f.fixedCosts {
+		open[i] = model.NewBool()
+		model.Objective().NewTerm(cost, open[i])
+	}
+
+	return open
+}
+
+// ship adds the shipments of scenario s from the facilities opened by the
+// variables to the model.
+func (f facilityLocation) ship(model mip.Model, s int, open mip.Vars) {
+	probability := 1 / float64(len(f.demands))
+	capacity := make([]mip.Constraint, len(f.capacities))
+	for i := range f.capacities {
+		capacity[i] = model.NewConstraint(mip.LessThanOrEqual, 0)
+		capacity[i].NewTerm(-f.capacities[i], open[i])
+	}
+	for j, demand := range f.demands[s] {
+		customer := model.NewConstraint(mip.GreaterThanOrEqual, demand)
+		for i := range f.capacities {
+			x := model.NewFloat(0, math.MaxFloat64)
+			model.Objective().NewTerm(probability*f.costs[i][j], x)
+			customer.NewTerm(1, x)
+			capacity[i].NewTerm(1, x)
+		}
+	}
+}
+
+// benders returns the Benders solver with a subproblem per scenario.
+func (f facilityLocation) benders() (*highs.BendersSolver, mip.Vars) {
+	master := mip.NewModel()
+	open := f.open(master)
+
+	subproblems := make([]*highs.BendersSubproblem, len(f.demands))
+	for s := range f.demands {
+		model := mip.NewModel()
+		linked := make(mip.Vars, len(f.fixedCosts))
+		for i := range linked {
+			linked[i] = model.NewFloat(0, 1)
+		}
+		f.ship(model, s, linked)
+
+		subproblems[s] = highs.NewBendersSubproblem(model)
+		for i := range linked {
+			subproblems[s].Link(open[i], linked[i])
+		}
+	}
+
+	return highs.NewBendersSolver(master, highs.DefaultOptions(), subproblems...), open
+}
+
+// monolithic returns the optimal value of the model with all scenarios.
+func (f facilityLocation) monolithic(t *testing.T) float64 {
+	model := mip.NewModel()
+	open := f.open(model)
+	for s := range f.demands {
+		f.ship(model, s, open)
+	}
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal monolithic solution")
+	}
+
+	return solution.ObjectiveValue()
+}
+
+func TestBenders(t *testing.T) {
+	want := facilities.monolithic(t)
+
+	for _, parallelism := range []int{1, 2} {
+		solver, open := facilities.benders()
+		solver.SetParallelism(parallelism)
+		result, err := solver.Solve(defaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Master.IsOptimal() {
+			t.Fatal("expected optimal master solution")
+		}
+		if math.Abs(result.UpperBound-want) > 1e-6 {
+			t.Errorf("got upper bound %v, want %v", result.UpperBound, want)
+		}
+		if result.LowerBound > result.UpperBound+1e-6 || result.Gap > 1e-4 {
+			t.Errorf("got bounds [%v, %v] with gap %v, want closed gap",
+				result.LowerBound, result.UpperBound, result.Gap)
+		}
+		if result.FeasibilityCuts == 0 || result.OptimalityCuts == 0 {
+			t.Errorf("got %d feasibility and %d optimality cuts, want both",
+				result.FeasibilityCuts, result.OptimalityCuts)
+		}
+
+		cost := 0.0
+		for i, v := range open {
+			cost += facilities.fixedCosts[i] * result.Master.Value(v)
+		}
+		for _, subproblem := range result.Subproblems {
+			cost += subproblem.ObjectiveValue()
+		}
+		if math.Abs(cost-result.Master.ObjectiveValue()) > 1e-6 {
+			t.Errorf("got cost %v, want the objective value %v",
+				cost, result.Master.ObjectiveValue())
+		}
+	}
+}
+
+func TestBendersInfeasible(t *testing.T) {
+	tooSmall := facilities
+	tooSmall.capacities = []float64{10, 10, 10}
+
+	solver, _ := tooSmall.benders()
+	result, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Master.IsOptimal() || !math.IsInf(result.UpperBound, 1) {
+		t.Errorf("got master status optimal %v and upper bound %v, want infeasible",
+			result.Master.IsOptimal(), result.UpperBound)
+	}
+	if result.FeasibilityCuts == 0 {
+		t.Error("expected feasibility cuts")
+	}
+}
@@ -0,0 +1,265 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+*/
+import "C"
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"time"
+	"unsafe"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// integralityTolerance is the distance from the nearest integer below which
+// the value of an integer column is treated as integral. It matches the
+// default mip_feasibility_tolerance of HiGHS.
+const integralityTolerance = 1e-6
+
+var errMiqpSemiNotSupported = errors.New(
+	"highs does not support semi-continuous or semi-integer variables " +
+		"in mixed integer quadratic programs",
+)
+
+// boundChange restricts an integer column to [lower, upper].
+type boundChange struct {
+	column int
+	lower  float64
+	upper  float64
+}
+
+// node is a subproblem of the branch-and-bound tree. Its bounds are the
+// bounds of the root with the changes applied in order.
+type node struct {
+	// bound is the objective value of the relaxation of the parent, as a
+	// minimization, which bounds the objective value of the node.
+	bound   float64
+	changes []boundChange
+}
+
+// nodeQueue orders nodes by best bound, deepest first among equal bounds so
+// that ties lead to incumbents sooner.
+type nodeQueue []*node
+
+func (q nodeQueue) Len() int { return len(q) }
+
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].bound != q[j].bound {
+		return q[i].bound < q[j].bound
+	}
+	return len(q[i].changes) > len(q[j].changes)
+}
+
+func (q nodeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue) Push(x any) { *q = append(*q, x.(*node)) }
+
+func (q *nodeQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return n
+}
+
+// branchAndBound solves a convex mixed integer quadratic program, which
+// HiGHS does not solve itself. It solves the QP relaxations of a best-bound
+// branch-and-bound tree with HiGHS, branching on the most fractional
+// integer column. Nodes are pruned when their bound is within the MIP gap
+// of the incumbent, and the search stops with the incumbent once the gap of
+// all open nodes is closed or the duration of the options is used up, in
+// which case the incumbent is reported as suboptimal. The MIP gaps are read
+// from the HiGHS instance, which holds the gaps of the solve options, the
+// options file and the control options in the precedence of
+// [handleOptions].
+func branchAndBound(
+	highsPtr unsafe.Pointer,
+	input *highsInput,
+	model mip.Model,
+	options mip.SolveOptions,
+) (*highsSolution, error) {
+	columns := make([]int, 0)
+	rootLower := make([]float64, 0)
+	rootUpper := make([]float64, 0)
+	for i, integrality := range input.columnIntegrality {
+		switch integrality {
+		case C.kHighsVarTypeSemiContinuous, C.kHighsVarTypeSemiInteger:
+			return nil, errMiqpSemiNotSupported
+		case C.kHighsVarTypeInteger:
+			columns = append(columns, i)
+			rootLower = append(rootLower, math.Ceil(float64(input.columnLowerBound[i])))
+			rootUpper = append(rootUpper, math.Floor(float64(input.columnUpperBound[i])))
+		}
+		input.columnIntegrality[i] = C.kHighsVarTypeContinuous
+	}
+	input.isIntegerProblem = false
+
+	gap, err := mipGap(highsPtr)
+	if err != nil {
+		return nil, err
+	}
+	options.MIP.Gap = gap
+
+	// The instance is owned by Solve, which checked the convexity.
+	relaxations := &instance{ptr: highsPtr, input: input, model: model}
+	if err := relaxations.pass(); err != nil {
+		return nil, err
+	}
+
+	// sign turns a maximization into a minimization.
+	sign := 1.0
+	if model.Objective().IsMaximize() {
+		sign = -1.0
+	}
+
+	deadline := input.start.Add(options.Duration)
+	lower := make([]float64, len(columns))
+	upper := make([]float64, len(columns))
+	position := make(map[int]int, len(columns))
+	for k, column := range columns {
+		position[column] = k
+	}
+
+	var incumbent *highsSolution
+	status := infeasible
+	queue := &nodeQueue{{bound: math.Inf(-1)}}
+	for queue.Len() > 0 {
+		n := heap.Pop(queue).(*node)
+		if incumbent != nil && gapClosed(sign*incumbent.objectiveValue, n.bound, options) {
+			// All open nodes have a bound at least as large.
+			queue = &nodeQueue{}
+			break
+		}
+
+		hasTime, err := relaxations.setDeadline(deadline)
+		if err != nil {
+			return nil, err
+		}
+		if !hasTime {
+			status = timeLimit
+			break
+		}
+
+		copy(lower, rootLower)
+		copy(upper, rootUpper)
+		for _, change := range n.changes {
+			lower[position[change.column]] = change.lower
+			upper[position[change.column]] = change.upper
+		}
+		if err := relaxations.changeColumnBounds(columns, lower, upper); err != nil {
+			return nil, err
+		}
+
+		relaxation, err := relaxations.run()
+		if err != nil {
+			return nil, err
+		}
+
+		switch relaxation.solutionStatus {
+		case optimal:
+		case infeasible:
+			continue
+		case timeLimit:
+			status = timeLimit
+			queue = &nodeQueue{}
+			continue
+		default:
+			// An unbounded relaxation makes the problem unbounded or
+			// infeasible, other statuses are failures of HiGHS.
+			return &highsSolution{
+				optionDeviations: relaxation.optionDeviations,
+				runtime:          time.Since(input.start),
+				solutionStatus:   relaxation.solutionStatus,
+			}, nil
+		}
+
+		bound := sign * relaxation.objectiveValue
+		if incumbent != nil && gapClosed(sign*incumbent.objectiveValue, bound, options) {
+			continue
+		}
+
+		column, value, fractional := mostFractional(relaxation.values, columns)
+		if !fractional {
+			for _, column := range columns {
+				relaxation.values[column] = math.Round(relaxation.values[column])
+			}
+			incumbent = relaxation
+			status = optimal
+			continue
+		}
+
+		k := position[column]
+		down := append(append([]boundChange(nil), n.changes...), boundChange{
+			column: column,
+			lower:  lower[k],
+			upper:  math.Floor(value),
+		})
+		up := append(append([]boundChange(nil), n.changes...), boundChange{
+			column: column,
+			lower:  math.Ceil(value),
+			upper:  upper[k],
+		})
+		heap.Push(queue, &node{bound: bound, changes: down})
+		heap.Push(queue, &node{bound: bound, changes: up})
+	}
+
+	if incumbent == nil {
+		return &highsSolution{
+			runtime:        time.Since(input.start),
+			solutionStatus: status,
+		}, nil
+	}
+	incumbent.solutionStatus = status
+	incumbent.suboptimal = status != optimal
+	incumbent.runtime = time.Since(input.start)
+
+	return incumbent, nil
+}
+
+// mostFractional returns the integer column whose value is farthest from an
+// integer, and false if all values are integral.
+func mostFractional(values []float64, columns []int) (int, float64, bool) {
+	best, bestValue, bestDistance := -1, 0.0, integralityTolerance
+	for _, column := range columns {
+		value := values[column]
+		distance := math.Abs(value - math.Round(value))
+		if distance > bestDistance {
+			best, bestValue, bestDistance = column, value, distance
+		}
+	}
+
+	return best, bestValue, best >= 0
+}
+
+// mipGap returns the absolute and relative MIP gaps set in HiGHS.
+func mipGap(highsPtr unsafe.Pointer) (mip.GapOptions, error) {
+	absolute, err := getDoubleOption(highsPtr, "mip_abs_gap")
+	if err != nil {
+		return mip.GapOptions{}, err
+	}
+	relative, err := getDoubleOption(highsPtr, "mip_rel_gap")
+	if err != nil {
+		return mip.GapOptions{}, err
+	}
+
+	return mip.GapOptions{Absolute: absolute, Relative: relative}, nil
+}
+
+// gapClosed reports whether a node with the bound cannot improve on the
+// incumbent by more than the absolute or relative MIP gap, both as
+// minimizations.
+func gapClosed(incumbent, bound float64, options mip.SolveOptions) bool {
+	gap := incumbent - bound
+	tolerance := math.Max(
+		options.MIP.Gap.Absolute,
+		options.MIP.Gap.Relative*math.Abs(incumbent),
+	)
+
+	return gap <= tolerance+1e-9*math.Max(1, math.Abs(incumbent))
+}
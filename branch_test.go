@@ -0,0 +1,173 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// lotSizing is the objective sum_i (x_i - target_i)^2 + (x_0 - x_1)^2 over
+// integers x_i in [0, 5] with sum_i x_i <= budget.
+type lotSizing struct {
+	targets []float64
+	budget  float64
+}
+
+func (l lotSizing) value(x []float64) float64 {
+	value := (x[0] - x[1]) * (x[0] - x[1])
+	for i, target := range l.targets {
+		value += (x[i] - target) * (x[i] - target)
+	}
+
+	return value
+}
+
+// bruteForce returns the optimal objective value by enumeration.
+func (l lotSizing) bruteForce() float64 {
+	best := math.Inf(1)
+	x := make([]float64, len(l.targets))
+	var enumerate func(i int, used float64)
+	enumerate = func(i int, used float64) {
+		if i == len(x) {
+			best = math.Min(best, l.value(x))
+			return
+		}
+		for v := 0.0; v <= 5 && used+v <= l.budget; v++ {
+			x[i] = v
+			enumerate(i+1, used+v)
+		}
+	}
+	enumerate(0, 0)
+
+	return best
+}
+
+func (l lotSizing) model(maximize bool) (*highs.Model, mip.Vars) {
+	sign := 1.0
+	model := highs.NewModel()
+	if maximize {
+		sign = -1.0
+		model.Objective().SetMaximize()
+	}
+
+	x := make(mip.Vars, len(l.targets))
+	budget := model.NewConstraint(mip.LessThanOrEqual, l.budget)
+	for i, target := range l.targets {
+		x[i] = model.NewInt(0, 5)
+		budget.NewTerm(1, x[i])
+		model.Objective().NewQuadraticTerm(sign, x[i], x[i])
+		model.Objective().NewTerm(-2*sign*target, x[i])
+		model.AddObjectiveConstant(sign * target * target)
+	}
+	model.Objective().NewQuadraticTerm(sign, x[0], x[0])
+	model.Objective().NewQuadraticTerm(sign, x[1], x[1])
+	model.Objective().NewQuadraticTerm(-2*sign, x[0], x[1])
+
+	return model, x
+}
+
+func TestBranchAndBound(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for run := 0; run < 10; run++ {
+		problem := lotSizing{
+			targets: []float64{r.Float64() * 5, r.Float64() * 5, r.Float64() * 5},
+			budget:  float64(r.Intn(10)),
+		}
+		want := problem.bruteForce()
+
+		for _, maximize := range []bool{false, true} {
+			model, x := problem.model(maximize)
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatalf("run %d: expected optimal solution", run)
+			}
+
+			got := solution.ObjectiveValue()
+			if maximize {
+				got = -got
+			}
+			if math.Abs(got-want) > 1e-5 {
+				t.Errorf("run %d, maximize %v: got objective %v, want %v", run, maximize, got, want)
+			}
+
+			values := make([]float64, len(x))
+			for i, v := range x {
+				values[i] = solution.Value(v)
+				if values[i] != math.Round(values[i]) {
+					t.Errorf("run %d: value %v of x_%d is not integral", run, values[i], i)
+				}
+			}
+			if math.Abs(problem.value(values)-want) > 1e-5 {
+				t.Errorf("run %d: got values %v with objective %v, want %v",
+					run, values, problem.value(values), want)
+			}
+		}
+	}
+}
+
+func TestBranchAndBoundInfeasible(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 5)
+	model.Objective().NewQuadraticTerm(1, x, x)
+	c := model.NewConstraint(mip.Equal, 2.5)
+	c.NewTerm(1, x)
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsInfeasible() {
+		t.Error("expected infeasible solution")
+	}
+}
+
+func TestBranchAndBoundTimeLimit(t *testing.T) {
+	problem := lotSizing{targets: []float64{1.5, 2.5, 3.5}, budget: 6}
+	model, _ := problem.model(false)
+
+	options := defaultOptions()
+	options.Duration = time.Nanosecond
+	solution, err := highs.NewSolver(model).Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsTimeOut() {
+		t.Error("expected time out")
+	}
+}
+
+func TestBranchAndBoundControlGaps(t *testing.T) {
+	problem := lotSizing{targets: []float64{1.5, 2.5, 3.5}, budget: 6}
+	want := problem.bruteForce()
+	model, _ := problem.model(false)
+
+	// The control options close the gaps the solve options leave open.
+	options := defaultOptions()
+	options.MIP.Gap.Absolute = 1e6
+	options.MIP.Gap.Relative = 1
+	options.Control.Float = "mip_abs_gap=0,mip_rel_gap=0"
+	solution, err := highs.NewSolver(model).Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if math.Abs(solution.ObjectiveValue()-want) > 1e-5 {
+		t.Errorf("got objective %v, want %v", solution.ObjectiveValue(), want)
+	}
+}
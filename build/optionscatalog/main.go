@@ -0,0 +1,559 @@
+// © 2019-present nextmv.io inc
+
+// Command optionscatalog generates the HiGHS option catalog of the highs
+// package from lp_data/HighsOptions.h. Constants used as names, defaults or
+// bounds are resolved from the other HiGHS headers.
+//
+//	go run ./build/optionscatalog \
+//		-include external/linux-amd64/include/highs \
+//		-out options_catalog.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	include := flag.String("include", "external/linux-amd64/include/highs", "HiGHS include directory")
+	out := flag.String("out", "options_catalog.go", "output file")
+	flag.Parse()
+
+	symbols, err := readSymbols(*include)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	header, err := os.ReadFile(filepath.Join(*include, "lp_data", "HighsOptions.h"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	records, err := parseRecords(string(header), symbols)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := render(records)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// record is a single option record of HighsOptions.h.
+type record struct {
+	kind        string
+	name        string
+	description string
+	advanced    bool
+	defaultVal  value
+	minVal      value
+	maxVal      value
+}
+
+// value is a resolved C++ constant, either a number or a string.
+type value struct {
+	number   float64
+	text     string
+	isString bool
+}
+
+var (
+	constPattern = regexp.MustCompile(
+		`(?m)^\s*const\s+(?:std::)?(?:string|HighsInt|double|bool|int)\s+(\w+)\s*=\s*([^;]+);`,
+	)
+	enumPattern = regexp.MustCompile(`(?s)enum\s+(?:class\s+)?\w*\s*(?::\s*\w+\s*)?\{(.*?)\};`)
+	recordStart = regexp.MustCompile(`new OptionRecord(Bool|Int|Double|String)\(`)
+)
+
+// readSymbols collects the constants and enum values of all headers below
+// the include directory.
+func readSymbols(include string) (map[string]value, error) {
+	raw := make(map[string]string)
+	var enums []string
+	err := filepath.WalkDir(include, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !(strings.HasSuffix(path, ".h") || strings.HasSuffix(path, ".hpp")) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		text := stripComments(string(data))
+		for _, m := range constPattern.FindAllStringSubmatch(text, -1) {
+			raw[m[1]] = strings.TrimSpace(m[2])
+		}
+		for _, m := range enumPattern.FindAllStringSubmatch(text, -1) {
+			enums = append(enums, m[1])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make(map[string]value)
+	var lookup func(name string) (value, error)
+	lookup = func(name string) (value, error) {
+		if v, ok := symbols[name]; ok {
+			return v, nil
+		}
+		expr, ok := raw[name]
+		if !ok {
+			return value{}, fmt.Errorf("unknown symbol %s", name)
+		}
+		// Remove the constant while it is evaluated to guard against
+		// cycles.
+		delete(raw, name)
+		v, err := evaluate(expr, lookup)
+		if err != nil {
+			return value{}, err
+		}
+		symbols[name] = v
+		return v, nil
+	}
+	resolve := func(expr string) (value, error) {
+		return evaluate(expr, lookup)
+	}
+
+	for _, body := range enums {
+		next := 0.0
+		for _, member := range strings.Split(body, ",") {
+			member = strings.TrimSpace(member)
+			if member == "" {
+				continue
+			}
+			name, expr, hasValue := strings.Cut(member, "=")
+			name = strings.TrimSpace(name)
+			if hasValue {
+				v, err := resolve(expr)
+				if err != nil {
+					// Enums referencing unknown symbols are not used by
+					// option records.
+					break
+				}
+				next = v.number
+			}
+			symbols[name] = value{number: next}
+			next++
+		}
+	}
+
+	for name := range raw {
+		// Constants that cannot be evaluated are not used by option
+		// records.
+		_, _ = lookup(name)
+	}
+
+	return symbols, nil
+}
+
+// parseRecords parses the option records of the initRecords method of
+// HighsOptions.h. Records inside preprocessor conditionals are skipped
+// because they are not part of the default build.
+func parseRecords(header string, symbols map[string]value) ([]record, error) {
+	start := strings.Index(header, "void initRecords()")
+	if start < 0 {
+		return nil, fmt.Errorf("initRecords not found")
+	}
+	body := stripComments(header[start:])
+	body = stripConditionals(body)
+
+	resolve := func(expr string) (value, error) {
+		return evaluate(expr, func(name string) (value, error) {
+			if v, ok := symbols[name]; ok {
+				return v, nil
+			}
+			return value{}, fmt.Errorf("unknown symbol %s", name)
+		})
+	}
+
+	advanced := false
+	records := make([]record, 0)
+	for {
+		loc := recordStart.FindStringSubmatchIndex(body)
+		if loc == nil {
+			break
+		}
+		if strings.Contains(body[:loc[0]], "advanced = true") {
+			advanced = true
+		}
+		kind := body[loc[2]:loc[3]]
+		args, end, err := splitArguments(body[loc[1]:])
+		if err != nil {
+			return nil, err
+		}
+		body = body[loc[1]+end:]
+
+		want := 5
+		if kind == "Int" || kind == "Double" {
+			want = 7
+		}
+		if len(args) != want {
+			return nil, fmt.Errorf("record %v: want %d arguments, got %d", args, want, len(args))
+		}
+
+		r := record{kind: kind, advanced: advanced}
+		name, err := resolve(args[0])
+		if err != nil {
+			return nil, err
+		}
+		description, err := resolve(args[1])
+		if err != nil {
+			return nil, err
+		}
+		r.name = name.text
+		r.description = description.text
+		if want == 7 {
+			if r.minVal, err = resolve(args[4]); err != nil {
+				return nil, fmt.Errorf("%s: %w", r.name, err)
+			}
+			if r.defaultVal, err = resolve(args[5]); err != nil {
+				return nil, fmt.Errorf("%s: %w", r.name, err)
+			}
+			if r.maxVal, err = resolve(args[6]); err != nil {
+				return nil, fmt.Errorf("%s: %w", r.name, err)
+			}
+		} else if r.defaultVal, err = resolve(args[4]); err != nil {
+			return nil, fmt.Errorf("%s: %w", r.name, err)
+		}
+		records = append(records, r)
+	}
+
+	return records, nil
+}
+
+// splitArguments splits the arguments of a call whose opening parenthesis
+// has already been consumed. It returns the arguments and the offset after
+// the closing parenthesis.
+func splitArguments(text string) ([]string, int, error) {
+	args := make([]string, 0)
+	depth := 0
+	inString := false
+	current := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			current.WriteByte(c)
+			if c == '\\' && i+1 < len(text) {
+				i++
+				current.WriteByte(text[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '(', '<':
+			depth++
+		case ')', '>':
+			if depth == 0 && c == ')' {
+				args = append(args, strings.TrimSpace(current.String()))
+				return args, i + 1, nil
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(current.String()))
+				current.Reset()
+				continue
+			}
+		}
+		current.WriteByte(c)
+	}
+
+	return nil, 0, fmt.Errorf("unterminated argument list")
+}
+
+// evaluate evaluates a C++ constant expression consisting of string
+// literals, numbers, symbols, std::numeric_limits and the operators +, -, *
+// and parentheses.
+func evaluate(expr string, lookup func(string) (value, error)) (value, error) {
+	p := &parser{text: strings.TrimSpace(expr), lookup: lookup}
+	v, err := p.sum()
+	if err != nil {
+		return value{}, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.text) {
+		return value{}, fmt.Errorf("unexpected %q in %q", p.text[p.pos:], expr)
+	}
+
+	return v, nil
+}
+
+type parser struct {
+	lookup func(string) (value, error)
+	text   string
+	pos    int
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.text) && unicode.IsSpace(rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) sum() (value, error) {
+	left, err := p.product()
+	if err != nil {
+		return value{}, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) || (p.text[p.pos] != '+' && p.text[p.pos] != '-') {
+			return left, nil
+		}
+		op := p.text[p.pos]
+		p.pos++
+		right, err := p.product()
+		if err != nil {
+			return value{}, err
+		}
+		if op == '+' {
+			left.number += right.number
+		} else {
+			left.number -= right.number
+		}
+	}
+}
+
+func (p *parser) product() (value, error) {
+	left, err := p.unary()
+	if err != nil {
+		return value{}, err
+	}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) || p.text[p.pos] != '*' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return value{}, err
+		}
+		left.number *= right.number
+	}
+}
+
+func (p *parser) unary() (value, error) {
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == '-' {
+		p.pos++
+		v, err := p.unary()
+		v.number = -v.number
+		return v, err
+	}
+
+	return p.primary()
+}
+
+var numericLimits = regexp.MustCompile(`^std::numeric_limits<(\w+)>::(max|infinity)\(\)`)
+
+func (p *parser) primary() (value, error) {
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return value{}, fmt.Errorf("unexpected end of %q", p.text)
+	}
+	rest := p.text[p.pos:]
+	c := rest[0]
+	switch {
+	case c == '"':
+		var sb strings.Builder
+		for p.pos < len(p.text) && p.text[p.pos] == '"' {
+			end := p.pos + 1
+			for end < len(p.text) && p.text[end] != '"' {
+				if p.text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			literal, err := strconv.Unquote(p.text[p.pos : end+1])
+			if err != nil {
+				return value{}, err
+			}
+			sb.WriteString(literal)
+			p.pos = end + 1
+			p.skipSpace()
+		}
+		return value{text: sb.String(), isString: true}, nil
+	case c == '(':
+		p.pos++
+		v, err := p.sum()
+		if err != nil {
+			return value{}, err
+		}
+		p.skipSpace()
+		if p.pos >= len(p.text) || p.text[p.pos] != ')' {
+			return value{}, fmt.Errorf("missing ) in %q", p.text)
+		}
+		p.pos++
+		return v, nil
+	case c >= '0' && c <= '9' || c == '.':
+		end := p.pos
+		for end < len(p.text) && (strings.ContainsRune("0123456789.eE", rune(p.text[end])) ||
+			(p.text[end] == '-' || p.text[end] == '+') && (p.text[end-1] == 'e' || p.text[end-1] == 'E')) {
+			end++
+		}
+		number, err := strconv.ParseFloat(p.text[p.pos:end], 64)
+		if err != nil {
+			return value{}, err
+		}
+		p.pos = end
+		return value{number: number}, nil
+	}
+
+	if m := numericLimits.FindStringSubmatch(rest); m != nil {
+		p.pos += len(m[0])
+		if m[2] == "infinity" {
+			return value{number: math.Inf(1)}, nil
+		}
+		switch m[1] {
+		case "HighsInt", "int":
+			return value{number: math.MaxInt32}, nil
+		case "int16_t":
+			return value{number: math.MaxInt16}, nil
+		}
+		return value{}, fmt.Errorf("unsupported numeric limit %s", m[0])
+	}
+
+	end := p.pos
+	for end < len(p.text) && (p.text[end] == '_' || unicode.IsLetter(rune(p.text[end])) ||
+		unicode.IsDigit(rune(p.text[end]))) {
+		end++
+	}
+	if end == p.pos {
+		return value{}, fmt.Errorf("unexpected %q", rest)
+	}
+	name := p.text[p.pos:end]
+	p.pos = end
+	switch name {
+	case "true":
+		return value{number: 1}, nil
+	case "false":
+		return value{number: 0}, nil
+	}
+
+	return p.lookup(name)
+}
+
+// stripComments removes // and /* */ comments outside of string literals.
+func stripComments(text string) string {
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case inString:
+			sb.WriteByte(c)
+			if c == '\\' && i+1 < len(text) {
+				i++
+				sb.WriteByte(text[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			sb.WriteByte(c)
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return sb.String()
+			}
+			i += end + 3
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+// stripConditionals removes #ifdef ... #endif blocks.
+func stripConditionals(text string) string {
+	var sb strings.Builder
+	depth := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#if"):
+			depth++
+			continue
+		case strings.HasPrefix(trimmed, "#endif"):
+			depth--
+			continue
+		}
+		if depth == 0 {
+			sb.WriteString(line)
+		}
+	}
+
+	return sb.String()
+}
+
+func render(records []record) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by build/optionscatalog from lp_data/HighsOptions.h. DO NOT EDIT.\n\n")
+	b.WriteString("package highs\n\nimport \"math\"\n\n")
+	b.WriteString("var optionCatalog = []OptionInfo{\n")
+	for _, r := range records {
+		fmt.Fprintf(&b, "{\nName: %q,\n", r.name)
+		switch r.kind {
+		case "Bool":
+			fmt.Fprintf(&b, "Type: OptionTypeBool,\nDefault: %v,\n", r.defaultVal.number != 0)
+		case "Int":
+			fmt.Fprintf(&b, "Type: OptionTypeInt,\nDefault: %d,\n", int64(r.defaultVal.number))
+			fmt.Fprintf(&b, "Min: %d,\nMax: %d,\n", int64(r.minVal.number), int64(r.maxVal.number))
+		case "Double":
+			fmt.Fprintf(&b, "Type: OptionTypeFloat,\nDefault: %s,\n", goFloat(r.defaultVal.number))
+			fmt.Fprintf(&b, "Min: %s,\nMax: %s,\n", goFloat(r.minVal.number), goFloat(r.maxVal.number))
+		case "String":
+			fmt.Fprintf(&b, "Type: OptionTypeString,\nDefault: %q,\n", r.defaultVal.text)
+		}
+		fmt.Fprintf(&b, "Advanced: %v,\nDescription: %q,\n},\n", r.advanced, r.description)
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func goFloat(number float64) string {
+	switch {
+	case math.IsInf(number, 1):
+		return "math.Inf(1)"
+	case math.IsInf(number, -1):
+		return "math.Inf(-1)"
+	}
+	text := strconv.FormatFloat(number, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+
+	return text
+}
@@ -0,0 +1,317 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+   #include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+//go:generate go run ./build/optionscatalog -include external/linux-amd64/include/highs -out options_catalog.go
+
+// OptionType is the type of the value of a HiGHS option. The names match the
+// groups of [mip.ControlOptions].
+type OptionType string
+
+// Types of HiGHS options.
+const (
+	// OptionTypeBool is the type of options with a bool value.
+	OptionTypeBool OptionType = "bool"
+	// OptionTypeInt is the type of options with an int value.
+	OptionTypeInt OptionType = "int"
+	// OptionTypeFloat is the type of options with a float (double) value.
+	OptionTypeFloat OptionType = "float"
+	// OptionTypeString is the type of options with a string value.
+	OptionTypeString OptionType = "string"
+)
+
+// OptionInfo describes a HiGHS option as defined in lp_data/HighsOptions.h.
+type OptionInfo struct {
+	// Name of the option.
+	Name string
+	// Type of the option value.
+	Type OptionType
+	// Default is the default value of the option. Its dynamic type is
+	// bool, int, float64 or string, depending on Type.
+	Default any
+	// Min is the smallest allowed value of an int or float option.
+	Min float64
+	// Max is the largest allowed value of an int or float option.
+	Max float64
+	// Advanced is true for options that are meant for HiGHS developers.
+	Advanced bool
+	// Description of the option.
+	Description string
+}
+
+// OptionCatalog returns all options known to the HiGHS version the package
+// is built with.
+func OptionCatalog() []OptionInfo {
+	catalog := make([]OptionInfo, len(optionCatalog))
+	copy(catalog, optionCatalog)
+
+	return catalog
+}
+
+// LookupOption returns the option with the given name. The second return
+// value is false if HiGHS does not know the option.
+func LookupOption(name string) (OptionInfo, bool) {
+	for _, option := range optionCatalog {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return OptionInfo{}, false
+}
+
+// OptionError is returned when an option is not valid for HiGHS.
+type OptionError struct {
+	// Name of the offending option.
+	Name string
+	// Reason describes why the option is not valid.
+	Reason string
+	// Suggestions holds known option names that are close to Name, if Name
+	// is not a HiGHS option.
+	Suggestions []string
+}
+
+func (e *OptionError) Error() string {
+	msg := fmt.Sprintf("HiGHS option %s: %s", e.Name, e.Reason)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(e.Suggestions, " or "))
+	}
+
+	return msg
+}
+
+// OptionDeviation is an option whose value differed from its default during
+// a solve.
+type OptionDeviation struct {
+	// Name of the option.
+	Name string `json:"name"`
+	// Value used during the solve.
+	Value any `json:"value"`
+	// Default value of the option.
+	Default any `json:"default"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. Infinite values are
+// encoded as "inf" and "-inf".
+func (deviation OptionDeviation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"name":    deviation.Name,
+		"value":   jsonOptionValue(deviation.Value),
+		"default": jsonOptionValue(deviation.Default),
+	})
+}
+
+func jsonOptionValue(value any) any {
+	if v, ok := value.(float64); ok {
+		return Bound(v)
+	}
+
+	return value
+}
+
+// validateOptions checks the control options, the typed options and the
+// options of the options file against the catalog. It returns the options
+// of the file, so the file is read only once.
+func validateOptions(options mip.SolveOptions, highsOptions Options) ([]optionValue, error) {
+	controlOptions, err := options.Control.ToTyped()
+	if err != nil {
+		return nil, err
+	}
+
+	errs := []error{validateControlOptions(controlOptions)}
+	var fileOptions []optionValue
+	if highsOptions.File != "" {
+		fileOptions, err = readOptionsFile(highsOptions.File)
+		errs = append(errs, err)
+	}
+	values, err := highsOptions.values()
+	errs = append(errs, err)
+	for _, option := range values {
+		errs = append(errs, validateOption(option.name, option.value))
+	}
+
+	return fileOptions, errors.Join(errs...)
+}
+
+// validateControlOptions checks the control options against the catalog and
+// returns all problems found.
+func validateControlOptions(controlOptions *mip.TypedControlOptions) error {
+	errs := make([]error, 0)
+	for _, option := range controlOptions.Bool {
+		errs = append(errs, validateOption(option.Name, option.Value))
+	}
+	for _, option := range controlOptions.Float {
+		errs = append(errs, validateOption(option.Name, option.Value))
+	}
+	for _, option := range controlOptions.Int {
+		errs = append(errs, validateOption(option.Name, option.Value))
+	}
+	for _, option := range controlOptions.String {
+		errs = append(errs, validateOption(option.Name, option.Value))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOption checks a single option value of type bool, int, float64 or
+// string against the catalog.
+func validateOption(name string, value any) error {
+	info, ok := LookupOption(name)
+	if !ok {
+		return &OptionError{
+			Name:        name,
+			Reason:      "unknown option",
+			Suggestions: suggestOptions(name),
+		}
+	}
+
+	var number float64
+	var valueType OptionType
+	switch v := value.(type) {
+	case bool:
+		valueType = OptionTypeBool
+	case int:
+		valueType = OptionTypeInt
+		number = float64(v)
+	case float64:
+		valueType = OptionTypeFloat
+		number = v
+	case string:
+		valueType = OptionTypeString
+	}
+
+	if valueType != info.Type {
+		return &OptionError{
+			Name:   name,
+			Reason: fmt.Sprintf("want a %s value, got %s value %v", info.Type, valueType, value),
+		}
+	}
+
+	if (valueType == OptionTypeInt || valueType == OptionTypeFloat) &&
+		(number < info.Min || number > info.Max || math.IsNaN(number)) {
+		return &OptionError{
+			Name: name,
+			Reason: fmt.Sprintf(
+				"value %v is outside of the range [%s, %s]",
+				value,
+				formatFloat(info.Min),
+				formatFloat(info.Max),
+			),
+		}
+	}
+
+	return nil
+}
+
+// suggestOptions returns up to three option names that are close to name.
+func suggestOptions(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	maxDistance := len(name)/3 + 1
+	candidates := make([]candidate, 0)
+	for _, option := range optionCatalog {
+		distance := levenshtein(name, option.Name)
+		if distance <= maxDistance ||
+			(len(name) > 3 && strings.Contains(option.Name, name)) {
+			candidates = append(candidates, candidate{option.Name, distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := make([]string, 0, 3)
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// maxStringOptionLength bounds the length of string option values read back
+// from HiGHS.
+const maxStringOptionLength = 4096
+
+// readOptionDeviations returns the options of the HiGHS instance that differ
+// from their default.
+func readOptionDeviations(highsPtr unsafe.Pointer) []OptionDeviation {
+	deviations := make([]OptionDeviation, 0)
+	buffer := (*C.char)(C.malloc(maxStringOptionLength))
+	defer C.free(unsafe.Pointer(buffer))
+
+	for _, option := range optionCatalog {
+		name := C.CString(option.Name)
+		var value any
+		var status C.HighsInt
+		switch option.Type {
+		case OptionTypeBool:
+			var v C.HighsInt
+			status = C.Highs_getBoolOptionValue(highsPtr, name, &v)
+			value = v != 0
+		case OptionTypeInt:
+			var v C.HighsInt
+			status = C.Highs_getIntOptionValue(highsPtr, name, &v)
+			value = int(v)
+		case OptionTypeFloat:
+			var v C.double
+			status = C.Highs_getDoubleOptionValue(highsPtr, name, &v)
+			value = float64(v)
+		case OptionTypeString:
+			status = C.Highs_getStringOptionValue(highsPtr, name, buffer)
+			value = C.GoString(buffer)
+		}
+		C.free(unsafe.Pointer(name))
+
+		if status == C.kHighsStatusOk && value != option.Default {
+			deviations = append(deviations, OptionDeviation{
+				Name:    option.Name,
+				Value:   value,
+				Default: option.Default,
+			})
+		}
+	}
+
+	return deviations
+}
@@ -0,0 +1,115 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestOptionCatalog(t *testing.T) {
+	option, ok := highs.LookupOption("mip_max_nodes")
+	if !ok {
+		t.Fatal("expected mip_max_nodes in the catalog")
+	}
+
+	if option.Type != highs.OptionTypeInt {
+		t.Errorf("got type %v, want %v", option.Type, highs.OptionTypeInt)
+	}
+
+	if option.Default != 2147483647 {
+		t.Errorf("got default %v, want 2147483647", option.Default)
+	}
+
+	if _, ok := highs.LookupOption("mip_max_node"); ok {
+		t.Error("expected mip_max_node not to be in the catalog")
+	}
+
+	for _, option := range highs.OptionCatalog() {
+		if option.Name == "" || option.Description == "" {
+			t.Errorf("incomplete catalog entry %+v", option)
+		}
+	}
+}
+
+func catalogModel() mip.Model {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	c := model.NewConstraint(mip.LessThanOrEqual, 7.5)
+	c.NewTerm(1, x)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+
+	return model
+}
+
+func TestControlOptionMisspelled(t *testing.T) {
+	options := defaultOptions()
+	options.Control.Int = "mip_max_node=10"
+
+	_, err := highs.NewSolver(catalogModel()).Solve(options)
+
+	var optionError *highs.OptionError
+	if !errors.As(err, &optionError) {
+		t.Fatalf("want OptionError, got %v", err)
+	}
+
+	if optionError.Name != "mip_max_node" {
+		t.Errorf("got name %v, want mip_max_node", optionError.Name)
+	}
+
+	if len(optionError.Suggestions) == 0 ||
+		optionError.Suggestions[0] != "mip_max_nodes" {
+		t.Errorf("got suggestions %v, want mip_max_nodes first",
+			optionError.Suggestions)
+	}
+}
+
+func TestControlOptionWrongTypeAndRange(t *testing.T) {
+	options := defaultOptions()
+	options.Control.Float = "threads=2.0,mip_heuristic_effort=1.5"
+
+	_, err := highs.NewSolver(catalogModel()).Solve(options)
+	if err == nil {
+		t.Fatal("Want error, got nil")
+	}
+
+	for _, name := range []string{"threads", "mip_heuristic_effort"} {
+		if !strings.Contains(err.Error(), "HiGHS option "+name+":") {
+			t.Errorf("expected an error for option %s, got %v", name, err)
+		}
+	}
+}
+
+func TestOptionDeviations(t *testing.T) {
+	options := defaultOptions()
+	options.Control.Int = "threads=1"
+	options.Control.String = "presolve=off"
+
+	solution, err := highs.NewSolver(catalogModel()).Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deviations := solution.(highs.Solution).OptionDeviations()
+	found := map[string]any{}
+	for _, d := range deviations {
+		found[d.Name] = d.Value
+	}
+
+	if found["threads"] != 1 {
+		t.Errorf("want threads=1 in deviations, got %v", deviations)
+	}
+
+	if found["presolve"] != "off" {
+		t.Errorf("want presolve=off in deviations, got %v", deviations)
+	}
+
+	if _, ok := found["mip_max_nodes"]; ok {
+		t.Errorf("did not want mip_max_nodes in deviations, got %v", deviations)
+	}
+}
@@ -5,7 +5,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"text/tabwriter"
 
 	"github.com/nextmv-io/go-highs"
 	"github.com/nextmv-io/go-mip"
@@ -18,15 +22,58 @@ import (
 // of many variables, subject to linear constraints. We demonstrate this by
 // solving the well known knapsack problem.
 func main() {
+	// The list-options subcommand prints all HiGHS options instead of
+	// solving.
+	if len(os.Args) > 1 && os.Args[1] == "list-options" {
+		if err := listOptions(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	err := run.CLI(solver).Run(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
+// listOptions prints the catalog of HiGHS options with their type, default
+// and range.
+func listOptions(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tDEFAULT\tRANGE\tDESCRIPTION")
+	for _, option := range highs.OptionCatalog() {
+		valueRange := "-"
+		if option.Type == highs.OptionTypeInt || option.Type == highs.OptionTypeFloat {
+			valueRange = fmt.Sprintf("[%v, %v]", option.Min, option.Max)
+		}
+		description := option.Description
+		if option.Advanced {
+			description = "(advanced) " + description
+		}
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%v\t%s\t%s\n",
+			option.Name,
+			option.Type,
+			option.Default,
+			valueRange,
+			description,
+		)
+	}
+
+	return tw.Flush()
+}
+
 // The options for the solver.
 type options struct {
 	Solve mip.SolveOptions `json:"solve,omitempty"`
+	Highs highsOptions     `json:"highs,omitempty"`
+}
+
+// highsOptions holds the options specific to the HiGHS solver.
+type highsOptions struct {
+	Options highs.Options `json:"options,omitempty"`
 }
 
 // Input of the problem.
@@ -54,7 +101,7 @@ func solver(_ context.Context, input input, options options) (schema.Output, err
 
 	// Create a solver using a provider. Please see the documentation on
 	// [mip.SolverProvider] for more information on the available providers.
-	solver := highs.NewSolver(model)
+	solver := highs.NewSolverWithOptions(model, options.Highs.Options)
 
 	// Solve the model and get the solution.
 	solution, err := solver.Solve(options.Solve)
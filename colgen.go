@@ -0,0 +1,302 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+*/
+import "C"
+
+import (
+	"math"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// Column is a column generated for the master problem of a
+// [ColumnGenerator], for example a cutting pattern or a route. It has a
+// cost in the objective of the master and coefficients in its constraints.
+type Column struct {
+	name         string
+	cost         float64
+	lower        float64
+	upper        float64
+	integer      bool
+	coefficients map[mip.Constraint]float64
+}
+
+// NewColumn creates a continuous column with a cost and bounds. Use
+// ±math.MaxFloat64 or ±Inf for a missing bound.
+func NewColumn(cost, lower, upper float64) *Column {
+	return &Column{
+		cost:         cost,
+		lower:        lower,
+		upper:        upper,
+		coefficients: make(map[mip.Constraint]float64),
+	}
+}
+
+// SetCoefficient sets the coefficient of the column in a constraint of the
+// master model.
+func (c *Column) SetCoefficient(constraint mip.Constraint, coefficient float64) {
+	c.coefficients[constraint] = coefficient
+}
+
+// Coefficient returns the coefficient of the column in a constraint.
+func (c *Column) Coefficient(constraint mip.Constraint) float64 {
+	return c.coefficients[constraint]
+}
+
+// Cost returns the cost of the column.
+func (c *Column) Cost() float64 {
+	return c.cost
+}
+
+// Lower returns the lower bound of the column.
+func (c *Column) Lower() float64 {
+	return c.lower
+}
+
+// Upper returns the upper bound of the column.
+func (c *Column) Upper() float64 {
+	return c.upper
+}
+
+// SetInteger sets whether the column is integer in the final MIP of the
+// column generation. It is continuous in the LPs.
+func (c *Column) SetInteger(integer bool) {
+	c.integer = integer
+}
+
+// IsInteger returns true if the column is integer in the final MIP.
+func (c *Column) IsInteger() bool {
+	return c.integer
+}
+
+// Name returns the name of the column.
+func (c *Column) Name() string {
+	return c.name
+}
+
+// SetName sets the name of the column.
+func (c *Column) SetName(name string) {
+	c.name = name
+}
+
+// ReducedCost returns the cost of the column minus its coefficients
+// weighted by the duals of the constraints in the solution. A column
+// improves the solution of a minimization if it is negative, and of a
+// maximization if it is positive.
+func (c *Column) ReducedCost(solution Solution) float64 {
+	reducedCost := c.cost
+	for constraint, coefficient := range c.coefficients {
+		reducedCost -= coefficient * solution.Dual(constraint)
+	}
+
+	return reducedCost
+}
+
+// Pricing returns new columns for the master problem given the solution of
+// the restricted master LP, whose duals are reported by [Solution.Dual]. It
+// returns no column once no column with an improving reduced cost exists.
+// Columns that do not improve the solution are ignored.
+type Pricing func(solution Solution) []*Column
+
+// ColumnGenerationResult is the result of a [ColumnGenerator].
+type ColumnGenerationResult struct {
+	// LP is the solution of the last restricted master LP.
+	LP Solution
+	// MIP is the solution of the final restricted master MIP. It is nil if
+	// the MIP was not solved.
+	MIP Solution
+	// Iterations is the number of restricted master LPs solved.
+	Iterations int
+	// Columns are the columns added to the master, in the order they were
+	// added. Their values are reported by [Solution.ColumnValue].
+	Columns []*Column
+	// Converged is true if the pricing found no improving column, so the
+	// LP solution is optimal for the master with all columns.
+	Converged bool
+}
+
+// ColumnGenerator solves the LP relaxation of a master problem with too
+// many columns to enumerate by column generation. The master is a model
+// with the initial columns as variables. The generator solves the
+// restricted master LP, passes its duals to a [Pricing] function and adds
+// the columns with an improving reduced cost it returns, until there are
+// none left. HiGHS warm starts every LP from the basis of the previous one.
+type ColumnGenerator struct {
+	model    mip.Model
+	options  Options
+	pricing  Pricing
+	solveMIP bool
+}
+
+// NewColumnGenerator creates a column generator for the master model with
+// columns returned by pricing, with the typed options applied to every
+// solve.
+func NewColumnGenerator(model mip.Model, options Options, pricing Pricing) *ColumnGenerator {
+	return &ColumnGenerator{
+		model:   model,
+		options: options,
+		pricing: pricing,
+	}
+}
+
+// SetSolveMIP sets whether the restricted master with all generated
+// columns is solved as a MIP once the LP converged, with the integer
+// variables of the model and the integer columns. This gives a heuristic
+// integer solution of the master, the LP is a bound on its optimal value.
+func (g *ColumnGenerator) SetSolveMIP(solveMIP bool) {
+	g.solveMIP = solveMIP
+}
+
+// Solve generates columns until the LP converges or the duration of the
+// options is used up, and then solves the MIP if requested. Constraints of
+// the master without terms are kept, so columns can cover them.
+func (g *ColumnGenerator) Solve(options mip.SolveOptions) (ColumnGenerationResult, error) {
+	start := time.Now()
+	result := ColumnGenerationResult{Columns: make([]*Column, 0)}
+
+	master, err := newInstance(g.model, g.options, options, start, true)
+	if err != nil {
+		return result, err
+	}
+	defer master.close()
+
+	// The LPs relax the integer variables of the model. A semi-continuous
+	// or semi-integer variable, 0 or in [l, u], is relaxed to
+	// [min(0, l), u].
+	input := master.input
+	integrality := append([]C.int(nil), input.columnIntegrality...)
+	semi := make([]int, 0)
+	semiLower := make([]float64, 0)
+	semiUpper := make([]float64, 0)
+	for i, t := range input.columnIntegrality {
+		if t == C.kHighsVarTypeSemiContinuous || t == C.kHighsVarTypeSemiInteger {
+			lower := float64(input.columnLowerBound[i])
+			semi = append(semi, i)
+			semiLower = append(semiLower, lower)
+			semiUpper = append(semiUpper, float64(input.columnUpperBound[i]))
+			input.columnLowerBound[i] = C.double(math.Min(0, lower))
+		}
+		input.columnIntegrality[i] = C.kHighsVarTypeContinuous
+	}
+	input.isIntegerProblem = false
+	if err := master.pass(); err != nil {
+		return result, err
+	}
+
+	sign := 1.0
+	if g.model.Objective().IsMaximize() {
+		sign = -1.0
+	}
+	deadline := start.Add(options.Duration)
+	columns := make(map[*Column]int)
+	for {
+		solution, err := g.run(master, deadline, columns, start)
+		if err != nil {
+			return result, err
+		}
+		result.LP = solution
+		if solution.solutionStatus == timeLimit {
+			return result, nil
+		}
+		result.Iterations++
+		if !solution.IsOptimal() {
+			return result, nil
+		}
+
+		added := make([]highsColumn, 0)
+		for _, column := range g.pricing(solution) {
+			if _, ok := columns[column]; ok {
+				continue
+			}
+			if sign*column.ReducedCost(solution) >= -g.options.dualFeasibilityTolerance() {
+				continue
+			}
+			columns[column] = input.numColumns + len(added)
+			result.Columns = append(result.Columns, column)
+			added = append(added, column.highsColumn(input.constraintRows))
+		}
+		if len(added) == 0 {
+			result.Converged = true
+			break
+		}
+		if _, err := master.addColumns(added); err != nil {
+			return result, err
+		}
+	}
+
+	if !g.solveMIP {
+		return result, nil
+	}
+
+	if err := master.changeColumnBounds(semi, semiLower, semiUpper); err != nil {
+		return result, err
+	}
+	copy(input.columnIntegrality, integrality)
+	integers := make([]int, 0)
+	for column, index := range columns {
+		if column.integer {
+			integers = append(integers, index)
+		}
+	}
+	if err := master.changeIntegrality(integers, true); err != nil {
+		return result, err
+	}
+
+	solution, err := g.run(master, deadline, columns, start)
+	if err != nil {
+		return result, err
+	}
+	result.MIP = solution
+
+	return result, nil
+}
+
+// run solves the restricted master until the deadline.
+func (g *ColumnGenerator) run(
+	master *instance,
+	deadline time.Time,
+	columns map[*Column]int,
+	start time.Time,
+) (*highsSolution, error) {
+	hasTime, err := master.setDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTime {
+		return &highsSolution{solutionStatus: timeLimit}, nil
+	}
+
+	solution, err := master.run()
+	if err != nil {
+		return nil, err
+	}
+	// The values of generated columns are kept after the values of the
+	// variables of the model.
+	solution.columns = columns
+	solution.runtime = time.Since(start)
+
+	return solution, nil
+}
+
+// highsColumn returns the column with terms over the rows of the
+// constraints.
+func (c *Column) highsColumn(rows map[mip.Constraint]int) highsColumn {
+	column := highsColumn{
+		cost:  c.cost,
+		lower: c.lower,
+		upper: c.upper,
+		terms: make([]linearTerm, 0, len(c.coefficients)),
+	}
+	for constraint, coefficient := range c.coefficients {
+		if row, ok := rows[constraint]; ok && coefficient != 0 {
+			column.terms = append(column.terms, linearTerm{index: row, coefficient: coefficient})
+		}
+	}
+
+	return column
+}
@@ -0,0 +1,238 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// Cutting stock: cut items of the widths from rolls of width rollWidth to
+// meet the demands with as few rolls as possible.
+var (
+	rollWidth = 10.0
+	widths    = []float64{3, 4, 5}
+	demands   = []float64{4, 3, 2}
+)
+
+// patterns returns all cutting patterns: the number of items of each width
+// cut from a roll.
+func patterns() [][]float64 {
+	all := make([][]float64, 0)
+	pattern := make([]float64, len(widths))
+	var cut func(i int, left float64)
+	cut = func(i int, left float64) {
+		if i == len(widths) {
+			all = append(all, append([]float64(nil), pattern...))
+			return
+		}
+		for n := 0.0; n*widths[i] <= left; n++ {
+			pattern[i] = n
+			cut(i+1, left-n*widths[i])
+		}
+	}
+	cut(0, rollWidth)
+
+	return all
+}
+
+// cuttingStock returns the master with one variable per pattern, starting
+// with the patterns that cut a single width.
+func cuttingStock(patterns [][]float64) (mip.Model, []mip.Constraint, mip.Vars) {
+	model := mip.NewModel()
+	demand := make([]mip.Constraint, len(widths))
+	for i := range widths {
+		demand[i] = model.NewConstraint(mip.GreaterThanOrEqual, demands[i])
+	}
+	rolls := make(mip.Vars, len(patterns))
+	for p, pattern := range patterns {
+		rolls[p] = model.NewInt(0, 100)
+		model.Objective().NewTerm(1, rolls[p])
+		for i, n := range pattern {
+			demand[i].NewTerm(n, rolls[p])
+		}
+	}
+
+	return model, demand, rolls
+}
+
+func TestColumnGeneration(t *testing.T) {
+	// The master MIP with all patterns.
+	full, _, _ := cuttingStock(patterns())
+	fullSolution, err := highs.NewSolver(full).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial := make([][]float64, len(widths))
+	for i := range widths {
+		initial[i] = make([]float64, len(widths))
+		initial[i][i] = math.Floor(rollWidth / widths[i])
+	}
+	model, demand, rolls := cuttingStock(initial)
+
+	// The pricing returns the pattern with the largest value of the items
+	// at their duals.
+	pricing := func(solution highs.Solution) []*highs.Column {
+		best, bestValue := []float64(nil), 0.0
+		for _, pattern := range patterns() {
+			value := 0.0
+			for i, n := range pattern {
+				value += n * solution.Dual(demand[i])
+			}
+			if value > bestValue {
+				best, bestValue = pattern, value
+			}
+		}
+		if best == nil || bestValue <= 1+1e-9 {
+			return nil
+		}
+		column := highs.NewColumn(1, 0, 100)
+		column.SetInteger(true)
+		for i, n := range best {
+			column.SetCoefficient(demand[i], n)
+		}
+		return []*highs.Column{column}
+	}
+
+	generator := highs.NewColumnGenerator(model, highs.DefaultOptions(), pricing)
+	generator.SetSolveMIP(true)
+	result, err := generator.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Converged {
+		t.Fatal("expected column generation to converge")
+	}
+
+	if len(result.Columns) == 0 || result.Iterations != len(result.Columns)+1 {
+		t.Errorf("got %d columns in %d iterations, want one column per iteration but the last",
+			len(result.Columns), result.Iterations)
+	}
+
+	// The converged LP is the LP relaxation of the master with all
+	// patterns.
+	lp := fullLP(t)
+	if math.Abs(result.LP.ObjectiveValue()-lp) > 1e-6 {
+		t.Errorf("got LP objective %v, want %v", result.LP.ObjectiveValue(), lp)
+	}
+
+	mipSolution := result.MIP
+	if mipSolution == nil || !mipSolution.IsOptimal() {
+		t.Fatal("expected optimal MIP")
+	}
+	if mipSolution.ObjectiveValue() < fullSolution.ObjectiveValue()-1e-6 {
+		t.Errorf("got MIP objective %v, below the optimum %v",
+			mipSolution.ObjectiveValue(), fullSolution.ObjectiveValue())
+	}
+
+	for i := range widths {
+		cut := 0.0
+		for p, v := range rolls {
+			cut += initial[p][i] * mipSolution.Value(v)
+		}
+		for _, column := range result.Columns {
+			value := mipSolution.ColumnValue(column)
+			if value != math.Round(value) {
+				t.Errorf("column value %v is not integral", value)
+			}
+			cut += column.Coefficient(demand[i]) * value
+		}
+		if cut < demands[i]-1e-6 {
+			t.Errorf("width %v: cut %v items, want at least %v", widths[i], cut, demands[i])
+		}
+	}
+}
+
+// fullLP returns the optimal value of the LP relaxation of the master with
+// all patterns.
+func fullLP(t *testing.T) float64 {
+	model := mip.NewModel()
+	demand := make([]mip.Constraint, len(widths))
+	for i := range widths {
+		demand[i] = model.NewConstraint(mip.GreaterThanOrEqual, demands[i])
+	}
+	for _, pattern := range patterns() {
+		x := model.NewFloat(0, 100)
+		model.Objective().NewTerm(1, x)
+		for i, n := range pattern {
+			demand[i].NewTerm(n, x)
+		}
+	}
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return solution.ObjectiveValue()
+}
+
+func TestDuals(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, math.MaxFloat64)
+	y := model.NewFloat(0, math.MaxFloat64)
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(1, y)
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 4)
+	c.NewTerm(1, x)
+	c.NewTerm(2, y)
+	empty := model.NewConstraint(mip.LessThanOrEqual, 1)
+	otherModel := mip.NewModel()
+	other := otherModel.NewConstraint(mip.LessThanOrEqual, 1)
+	other.NewTerm(1, otherModel.NewFloat(0, 1))
+
+	result, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution := result.(highs.Solution)
+
+	if math.Abs(solution.Dual(c)-0.5) > 1e-6 {
+		t.Errorf("got dual %v, want 0.5", solution.Dual(c))
+	}
+	if math.Abs(solution.ReducedCost(x)-0.5) > 1e-6 {
+		t.Errorf("got reduced cost of x %v, want 0.5", solution.ReducedCost(x))
+	}
+	if math.Abs(solution.ReducedCost(y)) > 1e-6 {
+		t.Errorf("got reduced cost of y %v, want 0", solution.ReducedCost(y))
+	}
+	if solution.Dual(empty) != 0 {
+		t.Errorf("got dual of the empty constraint %v, want 0", solution.Dual(empty))
+	}
+	if solution.Dual(other) != math.MaxFloat64 {
+		t.Errorf("got dual of a constraint of another model %v, want math.MaxFloat64",
+			solution.Dual(other))
+	}
+}
+
+func TestColumnGenerationSemiContinuous(t *testing.T) {
+	// minimize x with x = 0 or 2 <= x <= 5. The LP relaxes x to [0, 5].
+	model := highs.NewModel()
+	x := model.NewSemiContinuous(2, 5)
+	model.Objective().NewTerm(1, x)
+
+	pricing := func(highs.Solution) []*highs.Column { return nil }
+	generator := highs.NewColumnGenerator(model, highs.DefaultOptions(), pricing)
+	generator.SetSolveMIP(true)
+	result, err := generator.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.LP.IsOptimal() || !result.MIP.IsOptimal() {
+		t.Fatal("expected optimal LP and MIP solutions")
+	}
+
+	if result.LP.ObjectiveValue() > result.MIP.ObjectiveValue()+1e-9 {
+		t.Errorf("got LP bound %v above the MIP value %v",
+			result.LP.ObjectiveValue(), result.MIP.ObjectiveValue())
+	}
+	if math.Abs(result.MIP.Value(x)) > 1e-9 {
+		t.Errorf("got x %v, want 0", result.MIP.Value(x))
+	}
+}
@@ -0,0 +1,244 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// convexityTolerance is the tolerance, relative to the largest Hessian entry,
+// below which a pivot of the factorization is treated as zero.
+const convexityTolerance = 1e-9
+
+// NonConvexError is returned when the quadratic objective is not convex for
+// minimization or not concave for maximization. HiGHS can only solve QPs
+// with a positive semidefinite Hessian when minimizing and a negative
+// semidefinite one when maximizing.
+type NonConvexError struct {
+	// Vars are the variables of a direction along which the objective
+	// curves the wrong way.
+	Vars []mip.Var
+	// Direction holds the component of the direction for each of Vars,
+	// scaled so that the largest magnitude is 1.
+	Direction []float64
+	// Curvature is the value of the quadratic terms of the objective at
+	// the direction. It is negative for a minimization and positive for a
+	// maximization.
+	Curvature float64
+	// Maximize is true if the objective is maximized.
+	Maximize bool
+}
+
+func (e *NonConvexError) Error() string {
+	terms := make([]string, len(e.Vars))
+	for i, v := range e.Vars {
+		name := v.Name()
+		if name == "" {
+			name = fmt.Sprintf("variable %d", v.Index())
+		}
+		terms[i] = fmt.Sprintf("%g*%s", e.Direction[i], name)
+	}
+
+	kind := "convex"
+	if e.Maximize {
+		kind = "concave"
+	}
+
+	return fmt.Sprintf(
+		"highs: quadratic objective is not %s: its quadratic terms evaluate to %g along %s",
+		kind,
+		e.Curvature,
+		strings.Join(terms, " + "),
+	)
+}
+
+// checkConvexity returns a *NonConvexError if the quadratic objective of the
+// model is not convex for minimization or not concave for maximization. The
+// check is skipped if more than limit variables appear in quadratic terms or
+// if limit is 0, and abandoned without an error once the deadline passes.
+//
+// The check runs a sparse LDL^T factorization with diagonal pivoting on the
+// Hessian restricted to the variables of quadratic terms. A negative pivot,
+// or a remaining Schur complement with a zero diagonal and a non-zero
+// off-diagonal entry, gives a direction of negative curvature.
+func checkConvexity(model mip.Model, limit int, deadline time.Time) error {
+	objective := model.Objective()
+	if limit == 0 || !objective.IsQuadratic() {
+		return nil
+	}
+
+	builder := newHessianBuilder(len(model.Vars()))
+	builder.addTerms(objective.QuadraticTerms())
+	begins, indices, values := builder.build()
+
+	// Variables of quadratic terms and their position in the matrix.
+	positions := make(map[int]int)
+	columns := make([]int, 0)
+	add := func(index int) {
+		if _, ok := positions[index]; !ok {
+			positions[index] = len(columns)
+			columns = append(columns, index)
+		}
+	}
+	for column := 0; column+1 < len(begins); column++ {
+		for k := begins[column]; k < begins[column+1]; k++ {
+			add(column)
+			add(indices[k])
+		}
+	}
+	n := len(columns)
+	if n == 0 || n > limit {
+		return nil
+	}
+
+	sign := 1.0
+	if objective.IsMaximize() {
+		sign = -1.0
+	}
+
+	// a holds the rows of the symmetric matrix that are not eliminated,
+	// with the entries of eliminated columns removed.
+	a := make([]map[int]float64, n)
+	for i := range a {
+		a[i] = make(map[int]float64)
+	}
+	largest := 0.0
+	for column := 0; column+1 < len(begins); column++ {
+		for k := begins[column]; k < begins[column+1]; k++ {
+			i, j := positions[indices[k]], positions[column]
+			a[i][j] = sign * values[k]
+			a[j][i] = sign * values[k]
+			largest = math.Max(largest, math.Abs(values[k]))
+		}
+	}
+	tolerance := convexityTolerance * largest
+
+	// l[p] holds the multipliers of pivot p by row, order holds the pivots
+	// in elimination order.
+	l := make([]map[int]float64, n)
+	order := make([]int, 0, n)
+
+	direction := make([]float64, n)
+	found := false
+	for len(order) < n {
+		if time.Now().After(deadline) {
+			return nil
+		}
+
+		pivot := -1
+		for i := 0; i < n; i++ {
+			if a[i] != nil && (pivot < 0 || a[i][i] > a[pivot][pivot]) {
+				pivot = i
+			}
+		}
+		d := a[pivot][pivot]
+
+		if d < -tolerance {
+			direction[pivot] = 1
+			found = true
+			break
+		}
+
+		if d <= tolerance {
+			// The largest pivot is zero, a negative one is a direction of
+			// negative curvature. Otherwise all remaining pivots are zero
+			// and the Schur complement must vanish.
+			for i, row := range a {
+				if row != nil && row[i] < -tolerance {
+					direction[i] = 1
+					found = true
+					break
+				}
+			}
+			if !found {
+				found = zeroPivotDirection(a, tolerance, direction)
+			}
+			break
+		}
+
+		row := a[pivot]
+		a[pivot] = nil
+		order = append(order, pivot)
+		l[pivot] = make(map[int]float64, len(row))
+		for i, value := range row {
+			if i != pivot {
+				l[pivot][i] = value / d
+				delete(a[i], pivot)
+			}
+		}
+		for i, multiplier := range l[pivot] {
+			for j, value := range row {
+				if j != pivot {
+					a[i][j] -= multiplier * value
+				}
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	// Map the direction back to the original variables by solving
+	// L^T z = direction over the eliminated pivots.
+	for k := len(order) - 1; k >= 0; k-- {
+		p := order[k]
+		for i, multiplier := range l[p] {
+			direction[p] -= multiplier * direction[i]
+		}
+	}
+
+	scale := 0.0
+	for _, d := range direction {
+		scale = math.Max(scale, math.Abs(d))
+	}
+
+	vars := model.Vars()
+	err := &NonConvexError{
+		Vars:      make([]mip.Var, 0),
+		Direction: make([]float64, 0),
+		Maximize:  objective.IsMaximize(),
+	}
+	z := make(map[int]float64)
+	for i, d := range direction {
+		d /= scale
+		if math.Abs(d) <= convexityTolerance {
+			continue
+		}
+		err.Vars = append(err.Vars, vars[columns[i]])
+		err.Direction = append(err.Direction, d)
+		z[columns[i]] = d
+	}
+	for _, t := range objective.QuadraticTerms() {
+		err.Curvature += t.Coefficient() * z[t.Var1().Index()] * z[t.Var2().Index()]
+	}
+
+	return err
+}
+
+// zeroPivotDirection sets the direction to a direction of negative curvature
+// of a Schur complement with a zero diagonal, along the first pair of rows
+// with a non-zero off-diagonal entry. It returns false if the Schur
+// complement vanishes.
+func zeroPivotDirection(a []map[int]float64, tolerance float64, direction []float64) bool {
+	for i, row := range a {
+		pair := -1
+		for j, value := range row {
+			if j > i && math.Abs(value) > tolerance && (pair < 0 || j < pair) {
+				pair = j
+			}
+		}
+		if pair >= 0 {
+			direction[i] = 1
+			direction[pair] = -math.Copysign(1, row[pair])
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestNonConvexObjective(t *testing.T) {
+	// minimize x^2 + y^2 + 3xy + z^2 is not convex along x = -y.
+	model := mip.NewModel()
+	x := model.NewFloat(-1, 1)
+	x.SetName("x")
+	y := model.NewFloat(-1, 1)
+	y.SetName("y")
+	z := model.NewFloat(-1, 1)
+	model.Objective().NewQuadraticTerm(1, x, x)
+	model.Objective().NewQuadraticTerm(1, y, y)
+	model.Objective().NewQuadraticTerm(3, x, y)
+	model.Objective().NewQuadraticTerm(1, z, z)
+
+	_, err := highs.NewSolver(model).Solve(defaultOptions())
+
+	var nonConvexError *highs.NonConvexError
+	if !errors.As(err, &nonConvexError) {
+		t.Fatalf("want NonConvexError, got %v", err)
+	}
+
+	if len(nonConvexError.Vars) != 2 ||
+		nonConvexError.Vars[0].Name() != "x" ||
+		nonConvexError.Vars[1].Name() != "y" {
+		t.Errorf("got vars %v, want x and y", nonConvexError.Vars)
+	}
+
+	if nonConvexError.Curvature >= 0 {
+		t.Errorf("got curvature %v, want negative", nonConvexError.Curvature)
+	}
+
+	// A maximization of the same objective is not concave either.
+	model.Objective().SetMaximize()
+	_, err = highs.NewSolver(model).Solve(defaultOptions())
+	if !errors.As(err, &nonConvexError) || !nonConvexError.Maximize {
+		t.Fatalf("want NonConvexError for maximization, got %v", err)
+	}
+
+	if nonConvexError.Curvature <= 0 {
+		t.Errorf("got curvature %v, want positive", nonConvexError.Curvature)
+	}
+}
+
+func TestNonConvexVanishingSchurComplement(t *testing.T) {
+	// minimize (x+y)^2 - z^2: once x is eliminated the pivot of y is zero,
+	// while the pivot of z stays negative.
+	model := mip.NewModel()
+	x := model.NewFloat(-1, 1)
+	y := model.NewFloat(-1, 1)
+	z := model.NewFloat(-1, 1)
+	z.SetName("z")
+	model.Objective().NewQuadraticTerm(1, x, x)
+	model.Objective().NewQuadraticTerm(2, x, y)
+	model.Objective().NewQuadraticTerm(1, y, y)
+	model.Objective().NewQuadraticTerm(-1, z, z)
+
+	_, err := highs.NewSolver(model).Solve(defaultOptions())
+
+	var nonConvexError *highs.NonConvexError
+	if !errors.As(err, &nonConvexError) {
+		t.Fatalf("want NonConvexError, got %v", err)
+	}
+
+	if len(nonConvexError.Vars) != 1 || nonConvexError.Vars[0].Name() != "z" {
+		t.Errorf("got vars %v, want z", nonConvexError.Vars)
+	}
+
+	if nonConvexError.Curvature >= 0 {
+		t.Errorf("got curvature %v, want negative", nonConvexError.Curvature)
+	}
+}
+
+func TestConcaveMaximization(t *testing.T) {
+	// maximize x + y - x^2 - y^2 + 2xy - 4xy, a concave objective with a
+	// singular Hessian and split xy terms.
+	model := mip.NewModel()
+	x := model.NewFloat(-10, 10)
+	y := model.NewFloat(-10, 10)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(1, y)
+	model.Objective().NewQuadraticTerm(-1, x, x)
+	model.Objective().NewQuadraticTerm(-1, y, y)
+	model.Objective().NewQuadraticTerm(2, x, y)
+	model.Objective().NewQuadraticTerm(-4, x, y)
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (x+y) - (x+y)^2 is maximal at x+y = 1/2.
+	if math.Abs(solution.ObjectiveValue()-0.25) > 1e-6 {
+		t.Errorf("got %v, want 0.25", solution.ObjectiveValue())
+	}
+}
+
+func TestNonConvexSparseObjective(t *testing.T) {
+	// minimize sum_i x_i^2 + x_i x_{i+1} - z^2 has a tridiagonal Hessian
+	// that is positive definite except along z.
+	model := mip.NewModel()
+	x := make([]mip.Float, 1500)
+	for i := range x {
+		x[i] = model.NewFloat(-1, 1)
+		model.Objective().NewQuadraticTerm(1, x[i], x[i])
+		if i > 0 {
+			model.Objective().NewQuadraticTerm(1, x[i-1], x[i])
+		}
+	}
+	z := model.NewFloat(-1, 1)
+	z.SetName("z")
+	model.Objective().NewQuadraticTerm(-1, z, z)
+
+	_, err := highs.NewSolver(model).Solve(defaultOptions())
+
+	var nonConvexError *highs.NonConvexError
+	if !errors.As(err, &nonConvexError) {
+		t.Fatalf("want NonConvexError, got %v", err)
+	}
+
+	if len(nonConvexError.Vars) != 1 || nonConvexError.Vars[0].Name() != "z" {
+		t.Errorf("got vars %v, want z", nonConvexError.Vars)
+	}
+}
@@ -0,0 +1,150 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestViolatedEmptyConstraint(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+	model.Objective().NewTerm(1, x)
+
+	satisfied := model.NewConstraint(mip.LessThanOrEqual, 3)
+	satisfied.NewTerm(1, x)
+
+	// y - y <= -5 is 0 <= -5 once the terms cancel out.
+	cancelled := model.NewConstraint(mip.LessThanOrEqual, -5)
+	cancelled.NewTerm(1, y)
+	cancelled.NewTerm(-1, y)
+
+	model.NewConstraint(mip.GreaterThanOrEqual, 0)
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsInfeasible() || solution.IsOptimal() || solution.HasValues() {
+		t.Error("expected infeasible solution without values")
+	}
+
+	violated := solution.(highs.Solution).ViolatedConstraints()
+	if len(violated) != 1 || violated[0] != cancelled {
+		t.Errorf("got violated constraints %v, want the cancelled constraint", violated)
+	}
+}
+
+func TestSatisfiedEmptyConstraints(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(1, 10)
+	model.Objective().NewTerm(1, x)
+	model.NewConstraint(mip.LessThanOrEqual, 5)
+	model.NewConstraint(mip.GreaterThanOrEqual, -5)
+	model.NewConstraint(mip.Equal, 0)
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() || solution.Value(x) != 1 {
+		t.Errorf("expected optimal solution with x = 1, got %v", solution.Value(x))
+	}
+
+	if len(solution.(highs.Solution).ViolatedConstraints()) != 0 {
+		t.Error("expected no violated constraints")
+	}
+}
+
+func TestEmptyModel(t *testing.T) {
+	model := mip.NewModel()
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() || solution.IsInfeasible() {
+		t.Error("expected optimal empty model")
+	}
+
+	model.NewConstraint(mip.Equal, 1)
+	solution, err = highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if solution.IsOptimal() || !solution.IsInfeasible() {
+		t.Error("expected infeasible empty model with 0 = 1")
+	}
+}
+
+func TestViolatedEmptyConstraintDrivers(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	model.Objective().NewTerm(1, x)
+	cancelled := model.NewConstraint(mip.Equal, 1)
+	cancelled.NewTerm(1, x)
+	cancelled.NewTerm(-1, x)
+
+	drivers := map[string]func() (highs.Solution, error){
+		"alternatives": func() (highs.Solution, error) {
+			alternatives, err := highs.NewAlternativesSolver(model, highs.DefaultOptions(), 2).
+				Solve(defaultOptions())
+			if err != nil || len(alternatives) != 1 {
+				return nil, fmt.Errorf("got %d alternatives and error %v, want 1 alternative", len(alternatives), err)
+			}
+			return alternatives[0].Solution, nil
+		},
+		"lexicographic": func() (highs.Solution, error) {
+			objective := highs.NewLinearObjective(false)
+			objective.NewTerm(1, x)
+			solver := highs.NewLexicographicSolver(model, highs.DefaultOptions())
+			solver.AddStage(highs.LexicographicStage{Objective: objective})
+			results, err := solver.Solve(defaultOptions())
+			if err != nil || len(results) != 1 {
+				return nil, fmt.Errorf("got %d stages and error %v, want 1 stage", len(results), err)
+			}
+			return results[0].Solution, nil
+		},
+		"lazy": func() (highs.Solution, error) {
+			result, err := highs.NewLazySolver(
+				model,
+				highs.DefaultOptions(),
+				func(highs.Solution) []*highs.LazyConstraint { return nil },
+			).Solve(defaultOptions())
+			return result.Solution, err
+		},
+		"lns": func() (highs.Solution, error) {
+			solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+			solver.SetIterationDuration(time.Second)
+			result, err := solver.Solve(defaultOptions())
+			return result.Solution, err
+		},
+	}
+
+	for name, solve := range drivers {
+		solution, err := solve()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		if !solution.IsInfeasible() || solution.HasValues() {
+			t.Errorf("%s: expected infeasible solution without values", name)
+		}
+
+		violated := solution.ViolatedConstraints()
+		if len(violated) != 1 || violated[0] != cancelled {
+			t.Errorf("%s: got violated constraints %v, want the cancelled constraint", name, violated)
+		}
+	}
+}
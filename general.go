@@ -0,0 +1,346 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// GeneralKind is the function of a [GeneralConstraint].
+type GeneralKind string
+
+// Kinds of general constraints.
+const (
+	// GeneralAbs is y = |x|.
+	GeneralAbs GeneralKind = "abs"
+	// GeneralMin is y = min(x_1, ..., x_n).
+	GeneralMin GeneralKind = "min"
+	// GeneralMax is y = max(x_1, ..., x_n).
+	GeneralMax GeneralKind = "max"
+	// GeneralAnd is y = x_1 and ... and x_n over binaries.
+	GeneralAnd GeneralKind = "and"
+	// GeneralOr is y = x_1 or ... or x_n over binaries.
+	GeneralOr GeneralKind = "or"
+	// GeneralNot is y = not x over binaries.
+	GeneralNot GeneralKind = "not"
+)
+
+// GeneralConstraint is a constraint y = f(x_1, ..., x_n) for a non-linear
+// function f. [GeneralConstraint.AddTo] rewrites it into linear constraints,
+// with big-M values derived from the bounds of the operands, so the model
+// stays a plain [mip.Model].
+type GeneralConstraint struct {
+	kind     GeneralKind
+	result   mip.Var
+	operands mip.Vars
+	vars     mip.Vars
+	added    mip.Constraints
+}
+
+// NewAbs creates the general constraint y = |x|.
+func NewAbs(y, x mip.Var) *GeneralConstraint {
+	return newGeneralConstraint(GeneralAbs, y, x)
+}
+
+// NewMin creates the general constraint y = min(xs).
+func NewMin(y mip.Var, xs ...mip.Var) *GeneralConstraint {
+	return newGeneralConstraint(GeneralMin, y, xs...)
+}
+
+// NewMax creates the general constraint y = max(xs).
+func NewMax(y mip.Var, xs ...mip.Var) *GeneralConstraint {
+	return newGeneralConstraint(GeneralMax, y, xs...)
+}
+
+// NewAnd creates the general constraint y = xs_1 and ... and xs_n.
+func NewAnd(y mip.Bool, xs ...mip.Bool) *GeneralConstraint {
+	return newGeneralConstraint(GeneralAnd, y, toVars(xs)...)
+}
+
+// NewOr creates the general constraint y = xs_1 or ... or xs_n.
+func NewOr(y mip.Bool, xs ...mip.Bool) *GeneralConstraint {
+	return newGeneralConstraint(GeneralOr, y, toVars(xs)...)
+}
+
+// NewNot creates the general constraint y = not x.
+func NewNot(y, x mip.Bool) *GeneralConstraint {
+	return newGeneralConstraint(GeneralNot, y, x)
+}
+
+func newGeneralConstraint(kind GeneralKind, y mip.Var, xs ...mip.Var) *GeneralConstraint {
+	return &GeneralConstraint{
+		kind:     kind,
+		result:   y,
+		operands: append(mip.Vars(nil), xs...),
+	}
+}
+
+func toVars(bools []mip.Bool) mip.Vars {
+	vars := make(mip.Vars, len(bools))
+	for i, b := range bools {
+		vars[i] = b
+	}
+
+	return vars
+}
+
+// Kind returns the function of the constraint.
+func (g *GeneralConstraint) Kind() GeneralKind {
+	return g.kind
+}
+
+// Result returns y.
+func (g *GeneralConstraint) Result() mip.Var {
+	return g.result
+}
+
+// Operands returns a copy slice of x_1, ..., x_n.
+func (g *GeneralConstraint) Operands() mip.Vars {
+	return append(mip.Vars(nil), g.operands...)
+}
+
+// Vars returns the auxiliary variables added to the model.
+func (g *GeneralConstraint) Vars() mip.Vars {
+	return append(mip.Vars(nil), g.vars...)
+}
+
+// Constraints returns the constraints added to the model.
+func (g *GeneralConstraint) Constraints() mip.Constraints {
+	return append(mip.Constraints(nil), g.added...)
+}
+
+// Value returns f evaluated at the values of the operands in the solution.
+// Binaries are true if their value is at least 0.5, and and, or and not
+// return 1 for true and 0 for false.
+func (g *GeneralConstraint) Value(solution mip.Solution) float64 {
+	values := make([]float64, len(g.operands))
+	for i, x := range g.operands {
+		values[i] = solution.Value(x)
+	}
+
+	switch g.kind {
+	case GeneralAbs:
+		return math.Abs(values[0])
+	case GeneralMin:
+		value := math.Inf(1)
+		for _, v := range values {
+			value = math.Min(value, v)
+		}
+		return value
+	case GeneralMax:
+		value := math.Inf(-1)
+		for _, v := range values {
+			value = math.Max(value, v)
+		}
+		return value
+	case GeneralAnd:
+		for _, v := range values {
+			if v < 0.5 {
+				return 0
+			}
+		}
+		return 1
+	case GeneralOr:
+		for _, v := range values {
+			if v >= 0.5 {
+				return 1
+			}
+		}
+		return 0
+	case GeneralNot:
+		if values[0] >= 0.5 {
+			return 0
+		}
+		return 1
+	}
+
+	return math.NaN()
+}
+
+// AddTo adds the linear constraints of the general constraint to the model.
+// It returns an *UnboundedBigMError if the rewrite needs a bound of an
+// operand that is infinite, and an error if there are no operands.
+func (g *GeneralConstraint) AddTo(model mip.Model) error {
+	if len(g.operands) == 0 {
+		return fmt.Errorf("highs: %s constraint needs at least one operand", g.kind)
+	}
+
+	g.vars = make(mip.Vars, 0)
+	g.added = make(mip.Constraints, 0)
+
+	switch g.kind {
+	case GeneralAbs:
+		return g.addAbs(model)
+	case GeneralMin:
+		return g.addMinMax(model, false)
+	case GeneralMax:
+		return g.addMinMax(model, true)
+	case GeneralAnd:
+		g.addAnd(model)
+	case GeneralOr:
+		g.addOr(model)
+	case GeneralNot:
+		c := g.newConstraint(model, mip.Equal, 1)
+		c.NewTerm(1, g.result)
+		c.NewTerm(1, g.operands[0])
+	}
+
+	return nil
+}
+
+// addAbs adds y = |x|. If x has a sign, y = x or y = -x. Otherwise a binary
+// b is 1 if x >= 0 and
+//
+//	y >= x, y >= -x, y <= x - 2l(1-b), y <= -x + 2ub,
+//	x <= ub, x >= l(1-b)
+//
+// with x in [l, u], which includes 0 for semi-continuous and semi-integer
+// variables.
+func (g *GeneralConstraint) addAbs(model mip.Model) error {
+	x := g.operands[0]
+	l, u := varBounds(x, semiVars(model))
+	switch {
+	case l >= 0, u <= 0:
+		sign := 1.0
+		if u <= 0 && l < 0 {
+			sign = -1.0
+		}
+		c := g.newConstraint(model, mip.Equal, 0)
+		c.NewTerm(1, g.result)
+		c.NewTerm(-sign, x)
+		return nil
+	case isInfinite(l), isInfinite(u):
+		return &UnboundedBigMError{General: g, Var: x}
+	}
+
+	b := g.newBool(model)
+	for _, sign := range []float64{1, -1} {
+		c := g.newConstraint(model, mip.GreaterThanOrEqual, 0)
+		c.NewTerm(1, g.result)
+		c.NewTerm(-sign, x)
+	}
+
+	positive := g.newConstraint(model, mip.LessThanOrEqual, -2*l)
+	positive.NewTerm(1, g.result)
+	positive.NewTerm(-1, x)
+	positive.NewTerm(-2*l, b)
+
+	negative := g.newConstraint(model, mip.LessThanOrEqual, 0)
+	negative.NewTerm(1, g.result)
+	negative.NewTerm(1, x)
+	negative.NewTerm(-2*u, b)
+
+	upper := g.newConstraint(model, mip.LessThanOrEqual, 0)
+	upper.NewTerm(1, x)
+	upper.NewTerm(-u, b)
+
+	lower := g.newConstraint(model, mip.GreaterThanOrEqual, l)
+	lower.NewTerm(1, x)
+	lower.NewTerm(l, b)
+
+	return nil
+}
+
+// addMinMax adds y = max(x_i) as
+//
+//	y >= x_i, y <= x_i + (U - l_i)(1 - z_i), Σ z_i = 1
+//
+// with U the largest upper bound of the operands and a binary z_i that
+// selects the maximum. y = min(x_i) is the mirror image with L the smallest
+// lower bound. The bounds of semi-continuous and semi-integer variables
+// include 0.
+func (g *GeneralConstraint) addMinMax(model mip.Model, isMax bool) error {
+	if len(g.operands) == 1 {
+		c := g.newConstraint(model, mip.Equal, 0)
+		c.NewTerm(1, g.result)
+		c.NewTerm(-1, g.operands[0])
+		return nil
+	}
+
+	// sign turns a min into a max: min(x_i) = -max(-x_i).
+	semi := semiVars(model)
+	sign := 1.0
+	bound := func(v mip.Var) (float64, float64) { return varBounds(v, semi) }
+	if !isMax {
+		sign = -1.0
+		bound = func(v mip.Var) (float64, float64) {
+			l, u := varBounds(v, semi)
+			return -u, -l
+		}
+	}
+
+	largest := math.Inf(-1)
+	for _, x := range g.operands {
+		l, u := bound(x)
+		if isInfinite(l) || isInfinite(u) {
+			return &UnboundedBigMError{General: g, Var: x}
+		}
+		largest = math.Max(largest, u)
+	}
+
+	selection := g.newConstraint(model, mip.Equal, 1)
+	for _, x := range g.operands {
+		l, _ := bound(x)
+		m := largest - l
+		z := g.newBool(model)
+		selection.NewTerm(1, z)
+
+		// sign·y >= sign·x_i
+		dominates := g.newConstraint(model, mip.GreaterThanOrEqual, 0)
+		dominates.NewTerm(sign, g.result)
+		dominates.NewTerm(-sign, x)
+
+		// sign·y <= sign·x_i + m(1 - z_i)
+		selected := g.newConstraint(model, mip.LessThanOrEqual, m)
+		selected.NewTerm(sign, g.result)
+		selected.NewTerm(-sign, x)
+		selected.NewTerm(m, z)
+	}
+
+	return nil
+}
+
+// addAnd adds y <= x_i and y >= Σ x_i - (n - 1).
+func (g *GeneralConstraint) addAnd(model mip.Model) {
+	all := g.newConstraint(model, mip.GreaterThanOrEqual, -float64(len(g.operands)-1))
+	all.NewTerm(1, g.result)
+	for _, x := range g.operands {
+		c := g.newConstraint(model, mip.LessThanOrEqual, 0)
+		c.NewTerm(1, g.result)
+		c.NewTerm(-1, x)
+		all.NewTerm(-1, x)
+	}
+}
+
+// addOr adds y >= x_i and y <= Σ x_i.
+func (g *GeneralConstraint) addOr(model mip.Model) {
+	some := g.newConstraint(model, mip.LessThanOrEqual, 0)
+	some.NewTerm(1, g.result)
+	for _, x := range g.operands {
+		c := g.newConstraint(model, mip.GreaterThanOrEqual, 0)
+		c.NewTerm(1, g.result)
+		c.NewTerm(-1, x)
+		some.NewTerm(-1, x)
+	}
+}
+
+func (g *GeneralConstraint) newBool(model mip.Model) mip.Var {
+	v := model.NewBool()
+	g.vars = append(g.vars, v)
+
+	return v
+}
+
+func (g *GeneralConstraint) newConstraint(
+	model mip.Model,
+	sense mip.Sense,
+	rhs float64,
+) mip.Constraint {
+	c := model.NewConstraint(sense, rhs)
+	g.added = append(g.added, c)
+
+	return c
+}
@@ -0,0 +1,201 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestGeneralConstraintsNumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		general  func(y, x1, x2 mip.Var) *highs.GeneralConstraint
+		maximize bool
+		want     float64
+	}{
+		{
+			name:     "abs",
+			general:  func(y, x1, _ mip.Var) *highs.GeneralConstraint { return highs.NewAbs(y, x1) },
+			maximize: true,
+			want:     5,
+		},
+		{
+			name:    "abs minimized",
+			general: func(y, x1, _ mip.Var) *highs.GeneralConstraint { return highs.NewAbs(y, x1) },
+			want:    0,
+		},
+		{
+			name:     "max",
+			general:  func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMax(y, x1, x2) },
+			maximize: true,
+			want:     6,
+		},
+		{
+			name:    "max minimized",
+			general: func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMax(y, x1, x2) },
+			want:    1,
+		},
+		{
+			name:     "min",
+			general:  func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMin(y, x1, x2) },
+			maximize: true,
+			want:     3,
+		},
+		{
+			name:    "min minimized",
+			general: func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMin(y, x1, x2) },
+			want:    -5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := mip.NewModel()
+			x1 := model.NewFloat(-5, 3)
+			x2 := model.NewFloat(1, 6)
+			y := model.NewFloat(-100, 100)
+			if test.maximize {
+				model.Objective().SetMaximize()
+			}
+			model.Objective().NewTerm(1, y)
+
+			general := test.general(y, x1, x2)
+			if err := general.AddTo(model); err != nil {
+				t.Fatal(err)
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.Value(y)-test.want) > 1e-6 {
+				t.Errorf("got y = %v, want %v", solution.Value(y), test.want)
+			}
+
+			if math.Abs(general.Value(solution)-solution.Value(y)) > 1e-6 {
+				t.Errorf("got value %v, want y = %v", general.Value(solution), solution.Value(y))
+			}
+		})
+	}
+}
+
+func TestGeneralConstraintsSemiContinuous(t *testing.T) {
+	tests := []struct {
+		name    string
+		general func(y, x1, x2 mip.Var) *highs.GeneralConstraint
+		want    float64
+	}{
+		{
+			name:    "abs",
+			general: func(y, x1, _ mip.Var) *highs.GeneralConstraint { return highs.NewAbs(y, x1) },
+			want:    0,
+		},
+		{
+			name:    "max",
+			general: func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMax(y, x1, x2) },
+			want:    6,
+		},
+		{
+			name:    "min",
+			general: func(y, x1, x2 mip.Var) *highs.GeneralConstraint { return highs.NewMin(y, x1, x2) },
+			want:    0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// x1 = 0 or 2 <= x1 <= 5 is forced to 0, x2 = 6.
+			model := highs.NewModel()
+			x1 := model.NewSemiContinuous(2, 5)
+			x2 := model.NewFloat(6, 6)
+			y := model.NewFloat(-100, 100)
+			model.Objective().NewTerm(1, y)
+			zero := model.NewConstraint(mip.LessThanOrEqual, 1)
+			zero.NewTerm(1, x1)
+
+			if err := test.general(y, x1, x2).AddTo(model); err != nil {
+				t.Fatal(err)
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.Value(y)-test.want) > 1e-6 {
+				t.Errorf("got y = %v, want %v", solution.Value(y), test.want)
+			}
+		})
+	}
+}
+
+func TestGeneralConstraintsLogical(t *testing.T) {
+	for _, values := range [][2]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+		model := mip.NewModel()
+		a := model.NewBool()
+		b := model.NewBool()
+		for i, v := range []mip.Var{a, b} {
+			c := model.NewConstraint(mip.Equal, values[i])
+			c.NewTerm(1, v)
+		}
+
+		and, or, not := model.NewBool(), model.NewBool(), model.NewBool()
+		generals := map[mip.Var]*highs.GeneralConstraint{
+			and: highs.NewAnd(and, a, b),
+			or:  highs.NewOr(or, a, b),
+			not: highs.NewNot(not, a),
+		}
+		for _, general := range generals {
+			if err := general.AddTo(model); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		solution, err := highs.NewSolver(model).Solve(defaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !solution.IsOptimal() {
+			t.Fatal("expected optimal solution")
+		}
+
+		for y, general := range generals {
+			if math.Abs(solution.Value(y)-general.Value(solution)) > 1e-6 {
+				t.Errorf("%s of %v: got %v, want %v",
+					general.Kind(), values, solution.Value(y), general.Value(solution))
+			}
+		}
+	}
+}
+
+func TestGeneralConstraintUnbounded(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(-math.MaxFloat64, 5)
+	y := model.NewFloat(0, math.MaxFloat64)
+
+	abs := highs.NewAbs(y, x)
+	var unboundedError *highs.UnboundedBigMError
+	if err := abs.AddTo(model); !errors.As(err, &unboundedError) {
+		t.Fatalf("want UnboundedBigMError, got %v", err)
+	}
+
+	if unboundedError.General != abs || unboundedError.Var != x {
+		t.Errorf("got general constraint %v and var %v, want %v and %v",
+			unboundedError.General, unboundedError.Var, abs, x)
+	}
+}
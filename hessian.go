@@ -0,0 +1,82 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"sort"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// hessianBuilder assembles the Hessian of a quadratic objective in the
+// lower-triangular, column-wise format HiGHS expects.
+//
+// HiGHS minimizes c^Tx + 1/2 x^TQx. A term a*x_i*x_j with i != j
+// contributes a to both Q_ij and Q_ji, so the triangular entry is a. A term
+// a*x_i^2 contributes 2a to Q_ii. Duplicate terms and the terms (i,j) and
+// (j,i) are merged into the same entry; entries that merge to zero are
+// dropped.
+type hessianBuilder struct {
+	entries    map[hessianEntry]float64
+	numColumns int
+}
+
+// hessianEntry is a position in the lower triangle, row >= column.
+type hessianEntry struct {
+	column int
+	row    int
+}
+
+func newHessianBuilder(numColumns int) *hessianBuilder {
+	return &hessianBuilder{
+		entries:    make(map[hessianEntry]float64),
+		numColumns: numColumns,
+	}
+}
+
+// add adds the term coefficient*x_i*x_j.
+func (b *hessianBuilder) add(i, j int, coefficient float64) {
+	if i == j {
+		b.entries[hessianEntry{column: i, row: i}] += 2 * coefficient
+		return
+	}
+	b.entries[hessianEntry{column: min(i, j), row: max(i, j)}] += coefficient
+}
+
+// addTerms adds the quadratic terms of an objective.
+func (b *hessianBuilder) addTerms(terms mip.QuadraticTerms) {
+	for _, t := range terms {
+		b.add(t.Var1().Index(), t.Var2().Index(), t.Coefficient())
+	}
+}
+
+// build returns the column starts (of length numColumns+1), row indices and
+// values of the non-zero entries.
+func (b *hessianBuilder) build() (begins []int, indices []int, values []float64) {
+	entries := make([]hessianEntry, 0, len(b.entries))
+	for e, v := range b.entries {
+		if v != 0 {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].column != entries[j].column {
+			return entries[i].column < entries[j].column
+		}
+		return entries[i].row < entries[j].row
+	})
+
+	begins = make([]int, b.numColumns+1)
+	indices = make([]int, len(entries))
+	values = make([]float64, len(entries))
+	for k, e := range entries {
+		begins[e.column+1]++
+		indices[k] = e.row
+		values[k] = b.entries[e]
+	}
+	for i := 0; i < b.numColumns; i++ {
+		begins[i+1] += begins[i]
+	}
+
+	return begins, indices, values
+}
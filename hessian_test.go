@@ -0,0 +1,150 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+type quadraticTerm struct {
+	coefficient float64
+	var1, var2  mip.Var
+}
+
+func (t quadraticTerm) Coefficient() float64 { return t.coefficient }
+func (t quadraticTerm) Var1() mip.Var        { return t.var1 }
+func (t quadraticTerm) Var2() mip.Var        { return t.var2 }
+
+// rawObjective returns its quadratic terms as added, without merging
+// duplicates or ordering the variables of a term.
+type rawObjective struct {
+	mip.Objective
+	terms mip.QuadraticTerms
+}
+
+func (o *rawObjective) QuadraticTerms() mip.QuadraticTerms { return o.terms }
+
+type rawModel struct {
+	mip.Model
+	objective *rawObjective
+}
+
+func (m *rawModel) Objective() mip.Objective { return m.objective }
+
+// randomQP builds a convex QP whose quadratic terms include duplicates and
+// both orders of the same pair of variables. Off-diagonal terms are balanced
+// by diagonal terms so that the objective is diagonally dominant.
+func randomQP(r *rand.Rand) (*rawModel, []mip.Var) {
+	model := mip.NewModel()
+	n := 2 + r.Intn(5)
+	vars := make([]mip.Var, n)
+	for i := range vars {
+		vars[i] = model.NewFloat(-5, 5)
+		model.Objective().NewTerm(r.Float64()*10-5, vars[i])
+	}
+
+	terms := make(mip.QuadraticTerms, 0)
+	for k := 0; k < 3*n; k++ {
+		i, j := r.Intn(n), r.Intn(n)
+		coefficient := math.Round(r.Float64()*40-20) / 10
+		if i != j {
+			half := coefficient / 2
+			terms = append(terms,
+				quadraticTerm{half, vars[i], vars[j]},
+				quadraticTerm{half, vars[j], vars[i]},
+				quadraticTerm{math.Abs(coefficient) / 2, vars[i], vars[i]},
+				quadraticTerm{math.Abs(coefficient) / 2, vars[j], vars[j]},
+			)
+			continue
+		}
+		terms = append(terms,
+			quadraticTerm{math.Abs(coefficient) / 2, vars[i], vars[i]},
+			quadraticTerm{math.Abs(coefficient) / 2, vars[i], vars[i]},
+		)
+	}
+	// Terms that cancel each other out.
+	terms = append(terms,
+		quadraticTerm{1, vars[0], vars[1]},
+		quadraticTerm{-1, vars[1], vars[0]},
+	)
+	for _, v := range vars {
+		terms = append(terms, quadraticTerm{0.1, v, v})
+	}
+
+	return &rawModel{
+		Model: model,
+		objective: &rawObjective{
+			Objective: model.Objective(),
+			terms:     terms,
+		},
+	}, vars
+}
+
+func objectiveValue(model mip.Model, solution mip.Solution) float64 {
+	value := 0.0
+	for _, t := range model.Objective().Terms() {
+		value += t.Coefficient() * solution.Value(t.Var())
+	}
+	for _, t := range model.Objective().QuadraticTerms() {
+		value += t.Coefficient() * solution.Value(t.Var1()) * solution.Value(t.Var2())
+	}
+
+	return value
+}
+
+func TestHessianProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 50; i++ {
+		model, vars := randomQP(r)
+
+		solution, err := highs.NewSolver(model).Solve(defaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !solution.IsOptimal() {
+			t.Fatalf("model %d: expected optimal solution", i)
+		}
+
+		want := objectiveValue(model, solution)
+		got := solution.ObjectiveValue()
+		if math.Abs(got-want) > 1e-6*(1+math.Abs(want)) {
+			t.Errorf("model %d with %d vars: HiGHS objective %v, Go objective %v",
+				i, len(vars), got, want)
+		}
+	}
+}
+
+func TestHessianCancellingTerms(t *testing.T) {
+	// minimize x + x*y - y*x with only cancelling quadratic terms is a
+	// linear problem.
+	model := mip.NewModel()
+	x := model.NewFloat(1, 2)
+	y := model.NewFloat(1, 2)
+	model.Objective().NewTerm(1, x)
+
+	raw := &rawModel{
+		Model: model,
+		objective: &rawObjective{
+			Objective: model.Objective(),
+			terms: mip.QuadraticTerms{
+				quadraticTerm{1, x, y},
+				quadraticTerm{-1, y, x},
+			},
+		},
+	}
+
+	solution, err := highs.NewSolver(raw).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(solution.ObjectiveValue()-1) > 1e-9 {
+		t.Errorf("got %v, want 1", solution.ObjectiveValue())
+	}
+}
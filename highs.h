@@ -0,0 +1,71 @@
+// © 2019-present nextmv.io inc
+
+#ifndef GO_HIGHS_H
+#define GO_HIGHS_H
+
+// highs_c_api.h defines its constants in the header itself, so every file of
+// the package that included it would emit another definition of each
+// constant and the package would not link. Only solver.go includes it; the
+// other files include this header, which declares the parts of the C API
+// they use. solver.go includes both headers, so the compiler checks every
+// declaration against the HiGHS version the package is built with.
+
+#include "util/HighsInt.h"
+
+extern const HighsInt kHighsStatusError;
+extern const HighsInt kHighsStatusOk;
+extern const HighsInt kHighsVarTypeContinuous;
+extern const HighsInt kHighsVarTypeInteger;
+extern const HighsInt kHighsVarTypeSemiContinuous;
+extern const HighsInt kHighsVarTypeSemiInteger;
+extern const HighsInt kHighsObjSenseMinimize;
+extern const HighsInt kHighsObjSenseMaximize;
+extern const HighsInt kHighsSolutionStatusFeasible;
+
+void* Highs_create(void);
+void Highs_destroy(void* highs);
+HighsInt Highs_writeModel(void* highs, const char* filename);
+HighsInt Highs_getBoolOptionValue(const void* highs, const char* option,
+                                  HighsInt* value);
+HighsInt Highs_getIntOptionValue(const void* highs, const char* option,
+                                 HighsInt* value);
+HighsInt Highs_getDoubleOptionValue(const void* highs, const char* option,
+                                    double* value);
+HighsInt Highs_getStringOptionValue(const void* highs, const char* option,
+                                    char* value);
+HighsInt Highs_writeOptions(const void* highs, const char* filename);
+HighsInt Highs_getIntInfoValue(const void* highs, const char* info,
+                               HighsInt* value);
+HighsInt Highs_getDoubleInfoValue(const void* highs, const char* info,
+                                  double* value);
+HighsInt Highs_getDualRay(const void* highs, HighsInt* has_dual_ray,
+                          double* dual_ray_value);
+HighsInt Highs_setSolution(void* highs, const double* col_value,
+                           const double* row_value, const double* col_dual,
+                           const double* row_dual);
+HighsInt Highs_addCols(void* highs, const HighsInt num_new_col,
+                       const double* costs, const double* lower,
+                       const double* upper, const HighsInt num_new_nz,
+                       const HighsInt* starts, const HighsInt* index,
+                       const double* value);
+HighsInt Highs_addRows(void* highs, const HighsInt num_new_row,
+                       const double* lower, const double* upper,
+                       const HighsInt num_new_nz, const HighsInt* starts,
+                       const HighsInt* index, const double* value);
+HighsInt Highs_changeObjectiveSense(void* highs, const HighsInt sense);
+HighsInt Highs_changeColsIntegralityByRange(void* highs,
+                                            const HighsInt from_col,
+                                            const HighsInt to_col,
+                                            const HighsInt* integrality);
+HighsInt Highs_changeColsCostByRange(void* highs, const HighsInt from_col,
+                                     const HighsInt to_col, const double* cost);
+HighsInt Highs_changeColsBoundsBySet(void* highs,
+                                     const HighsInt num_set_entries,
+                                     const HighsInt* set, const double* lower,
+                                     const double* upper);
+HighsInt Highs_changeRowsBoundsBySet(void* highs,
+                                     const HighsInt num_set_entries,
+                                     const HighsInt* set, const double* lower,
+                                     const double* upper);
+
+#endif
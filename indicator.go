@@ -0,0 +1,211 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// IndicatorConstraint is a constraint "if indicator = 1 then a·x sense rhs".
+// HiGHS has no native indicator constraints, so [IndicatorConstraint.AddTo]
+// reformulates it into linear constraints with a big-M derived from the
+// bounds of the variables.
+type IndicatorConstraint struct {
+	name      string
+	indicator mip.Bool
+	sense     mip.Sense
+	rhs       float64
+	terms     mip.Terms
+	lowerM    float64
+	upperM    float64
+	added     mip.Constraints
+}
+
+// NewIndicatorConstraint creates the indicator constraint "if indicator = 1
+// then a·x sense rhs". Add terms with [IndicatorConstraint.NewTerm] and add
+// it to a model with [IndicatorConstraint.AddTo].
+func NewIndicatorConstraint(
+	indicator mip.Bool,
+	sense mip.Sense,
+	rhs float64,
+) *IndicatorConstraint {
+	return &IndicatorConstraint{
+		indicator: indicator,
+		sense:     sense,
+		rhs:       rhs,
+		terms:     make(mip.Terms, 0),
+	}
+}
+
+// NewTerm adds a term to the constraint. Invoking it multiple times for the
+// same variable sums the coefficients.
+func (c *IndicatorConstraint) NewTerm(coefficient float64, variable mip.Var) mip.Term {
+	if math.IsNaN(coefficient) {
+		panic("indicator constraint term coefficient is NaN")
+	}
+
+	t := term{coefficient: coefficient, variable: variable}
+	c.terms = append(c.terms, t)
+
+	return t
+}
+
+// SetName sets the name of the constraint. The constraints added to the
+// model are named after it with the suffix _le or _ge.
+func (c *IndicatorConstraint) SetName(name string) {
+	c.name = name
+}
+
+// Name returns the name of the constraint.
+func (c *IndicatorConstraint) Name() string {
+	return c.name
+}
+
+// Indicator returns the binary variable of the constraint.
+func (c *IndicatorConstraint) Indicator() mip.Bool {
+	return c.indicator
+}
+
+// Terms returns the terms of the constraint, with the coefficients of the
+// terms of the same variable summed and zero terms dropped.
+func (c *IndicatorConstraint) Terms() mip.Terms {
+	return uniqueTerms(c.terms)
+}
+
+// BigM returns the M values used by the reformulation. lower is the M of
+// a·x >= rhs, the amount by which a·x can fall below rhs. upper is the M of
+// a·x <= rhs, the amount by which a·x can exceed rhs. An M is 0 if the
+// constraint holds for all values of the variables, in which case no
+// constraint is added for it.
+func (c *IndicatorConstraint) BigM() (lower, upper float64) {
+	return c.lowerM, c.upperM
+}
+
+// Constraints returns the constraints added to the model by
+// [IndicatorConstraint.AddTo].
+func (c *IndicatorConstraint) Constraints() mip.Constraints {
+	return append(mip.Constraints(nil), c.added...)
+}
+
+// AddTo adds the big-M reformulation of the constraint to the model:
+//
+//	a·x + M·indicator <= rhs + M    for a·x <= rhs, M = max(a·x) - rhs
+//	a·x - M·indicator >= rhs - M    for a·x >= rhs, M = rhs - min(a·x)
+//
+// The minimum and maximum of a·x are derived from the variable bounds with
+// interval arithmetic, which gives the tightest M valid for all values of
+// the variables. The bounds of semi-continuous and semi-integer variables
+// include 0. An equality uses both constraints. It returns an
+// *UnboundedBigMError if a variable is unbounded in the direction the M
+// depends on.
+func (c *IndicatorConstraint) AddTo(model mip.Model) error {
+	vars := model.Vars()
+	semi := semiVars(model)
+	terms := linearTerms(c.Terms())
+
+	minActivity, maxActivity := 0.0, 0.0
+	var minUnbounded, maxUnbounded mip.Var
+	for _, t := range terms {
+		v := vars[t.index]
+		lower, upper := varBounds(v, semi)
+		low := t.coefficient * lower
+		high := t.coefficient * upper
+		lowInfinite, highInfinite := isInfinite(lower), isInfinite(upper)
+		if t.coefficient < 0 {
+			low, high = high, low
+			lowInfinite, highInfinite = highInfinite, lowInfinite
+		}
+		if lowInfinite && minUnbounded == nil {
+			minUnbounded = v
+		}
+		if highInfinite && maxUnbounded == nil {
+			maxUnbounded = v
+		}
+		minActivity += low
+		maxActivity += high
+	}
+
+	needsUpper := c.sense == mip.LessThanOrEqual || c.sense == mip.Equal
+	needsLower := c.sense == mip.GreaterThanOrEqual || c.sense == mip.Equal
+	if needsUpper && maxUnbounded != nil {
+		return &UnboundedBigMError{Constraint: c, Var: maxUnbounded}
+	}
+	if needsLower && minUnbounded != nil {
+		return &UnboundedBigMError{Constraint: c, Var: minUnbounded}
+	}
+
+	c.lowerM, c.upperM = 0, 0
+	c.added = make(mip.Constraints, 0, 2)
+	if needsUpper {
+		c.upperM = math.Max(0, maxActivity-c.rhs)
+		if c.upperM > 0 {
+			c.add(model, terms, vars, mip.LessThanOrEqual, c.upperM, "_le")
+		}
+	}
+	if needsLower {
+		c.lowerM = math.Max(0, c.rhs-minActivity)
+		if c.lowerM > 0 {
+			c.add(model, terms, vars, mip.GreaterThanOrEqual, -c.lowerM, "_ge")
+		}
+	}
+
+	return nil
+}
+
+// add adds a·x + m·indicator sense rhs + m.
+func (c *IndicatorConstraint) add(
+	model mip.Model,
+	terms []linearTerm,
+	vars mip.Vars,
+	sense mip.Sense,
+	m float64,
+	suffix string,
+) {
+	constraint := model.NewConstraint(sense, c.rhs+m)
+	if c.name != "" {
+		constraint.SetName(c.name + suffix)
+	}
+	for _, t := range terms {
+		constraint.NewTerm(t.coefficient, vars[t.index])
+	}
+	constraint.NewTerm(m, c.indicator)
+	c.added = append(c.added, constraint)
+}
+
+// UnboundedBigMError is returned when an indicator or general constraint
+// cannot be reformulated because a variable of the constraint is unbounded,
+// so no finite M is valid.
+type UnboundedBigMError struct {
+	// Constraint is the indicator constraint, nil for general constraints.
+	Constraint *IndicatorConstraint
+	// General is the general constraint, nil for indicator constraints.
+	General *GeneralConstraint
+	// Var is a variable whose infinite bound makes M unbounded.
+	Var mip.Var
+}
+
+func (e *UnboundedBigMError) Error() string {
+	name := e.Var.Name()
+	if name == "" {
+		name = fmt.Sprintf("variable %d", e.Var.Index())
+	}
+	constraint := "general constraint"
+	if e.General != nil {
+		constraint = fmt.Sprintf("%s constraint", e.General.Kind())
+	}
+	if e.Constraint != nil {
+		constraint = "indicator constraint"
+		if e.Constraint.Name() != "" {
+			constraint += " " + e.Constraint.Name()
+		}
+	}
+
+	return fmt.Sprintf(
+		"highs: big-M of %s is unbounded, %s needs finite bounds",
+		constraint,
+		name,
+	)
+}
@@ -0,0 +1,151 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestIndicatorConstraint(t *testing.T) {
+	tests := []struct {
+		name      string
+		sense     mip.Sense
+		rhs       float64
+		objective float64
+		lowerM    float64
+		upperM    float64
+		want      float64
+	}{
+		// maximize x + y + 18b, if b = 1 then x + y <= 3
+		{
+			name:      "less than or equal",
+			sense:     mip.LessThanOrEqual,
+			rhs:       3,
+			objective: 18,
+			upperM:    17,
+			want:      21,
+		},
+		// maximize x + y - 30b, if b = 1 then x + y >= 12
+		{
+			name:      "greater than or equal",
+			sense:     mip.GreaterThanOrEqual,
+			rhs:       12,
+			objective: -30,
+			lowerM:    12,
+			want:      20,
+		},
+		// maximize x + y + 16b, if b = 1 then x + y = 5
+		{
+			name:      "equal",
+			sense:     mip.Equal,
+			rhs:       5,
+			objective: 16,
+			lowerM:    5,
+			upperM:    15,
+			want:      21,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := mip.NewModel()
+			x := model.NewFloat(0, 10)
+			y := model.NewInt(0, 10)
+			b := model.NewBool()
+			model.Objective().SetMaximize()
+			model.Objective().NewTerm(1, x)
+			model.Objective().NewTerm(1, y)
+			model.Objective().NewTerm(test.objective, b)
+
+			indicator := highs.NewIndicatorConstraint(b, test.sense, test.rhs)
+			indicator.NewTerm(1, x)
+			indicator.NewTerm(1, y)
+			if err := indicator.AddTo(model); err != nil {
+				t.Fatal(err)
+			}
+
+			lowerM, upperM := indicator.BigM()
+			if lowerM != test.lowerM || upperM != test.upperM {
+				t.Errorf("got M values %v and %v, want %v and %v",
+					lowerM, upperM, test.lowerM, test.upperM)
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.ObjectiveValue()-test.want) > 1e-6 {
+				t.Errorf("got %v, want %v", solution.ObjectiveValue(), test.want)
+			}
+		})
+	}
+}
+
+func TestIndicatorConstraintSemiContinuous(t *testing.T) {
+	// minimize x - b with x = 0 or 2 <= x <= 5, if b = 1 then x >= 3. The M
+	// must allow x = 0 when b = 0.
+	model := highs.NewModel()
+	x := model.NewSemiContinuous(2, 5)
+	b := model.NewBool()
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(-1, b)
+
+	indicator := highs.NewIndicatorConstraint(b, mip.GreaterThanOrEqual, 3)
+	indicator.NewTerm(1, x)
+	if err := indicator.AddTo(model); err != nil {
+		t.Fatal(err)
+	}
+
+	if lowerM, _ := indicator.BigM(); lowerM != 3 {
+		t.Errorf("got lower M %v, want 3", lowerM)
+	}
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if math.Abs(solution.Value(x)) > 1e-6 || math.Abs(solution.Value(b)) > 1e-6 {
+		t.Errorf("got x %v and b %v, want 0 and 0", solution.Value(x), solution.Value(b))
+	}
+}
+
+func TestIndicatorConstraintUnbounded(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, math.MaxFloat64)
+	x.SetName("x")
+	b := model.NewBool()
+
+	ge := highs.NewIndicatorConstraint(b, mip.GreaterThanOrEqual, 5)
+	ge.NewTerm(1, x)
+	if err := ge.AddTo(model); err != nil {
+		t.Fatal(err)
+	}
+
+	le := highs.NewIndicatorConstraint(b, mip.LessThanOrEqual, 5)
+	le.NewTerm(1, x)
+
+	var unboundedError *highs.UnboundedBigMError
+	if err := le.AddTo(model); !errors.As(err, &unboundedError) ||
+		unboundedError.Var != x {
+		t.Fatalf("want UnboundedBigMError for x, got %v", err)
+	}
+
+	if len(model.Constraints()) != 1 {
+		t.Errorf("got %d constraints, want 1", len(model.Constraints()))
+	}
+}
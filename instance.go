@@ -0,0 +1,586 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+   #include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+var (
+	errChangingBounds = errors.New(
+		"highs failed changing column bounds",
+	)
+	errChangingCosts = errors.New(
+		"highs failed changing column costs",
+	)
+	errChangingSense = errors.New(
+		"highs failed changing the objective sense",
+	)
+	errChangingRowBounds = errors.New(
+		"highs failed changing row bounds",
+	)
+	errAddingRows = errors.New(
+		"highs failed adding rows",
+	)
+	errSettingSolution = errors.New(
+		"highs failed setting the solution",
+	)
+	errAddingColumns = errors.New(
+		"highs failed adding columns",
+	)
+	errChangingIntegrality = errors.New(
+		"highs failed changing the integrality of columns",
+	)
+	errNoVars = errors.New(
+		"highs: model has no variables",
+	)
+	errGettingDualRay = errors.New(
+		"highs failed getting the dual ray",
+	)
+	errGettingInfo = errors.New(
+		"highs failed getting an info value",
+	)
+	errInstanceMiqp = errors.New(
+		"highs: models with a quadratic objective and integer variables " +
+			"can only be solved with Solve",
+	)
+)
+
+// instance is a HiGHS instance holding a model that is changed and solved
+// repeatedly, for the algorithms built on top of HiGHS. HiGHS keeps the
+// basis and solution between runs, so re-solves after a change are warm
+// started.
+type instance struct {
+	ptr   unsafe.Pointer
+	input *highsInput
+	// model is the model of the input, convexityCheckLimit the limit of
+	// the convexity check of its quadratic objective and deadline the end
+	// of the duration of the solve.
+	model               mip.Model
+	convexityCheckLimit int
+	deadline            time.Time
+	// optionDeviations are the option deviations of the first run, shared
+	// by the solutions of all runs.
+	optionDeviations []OptionDeviation
+}
+
+// newInstance creates a HiGHS instance for the model with the options set.
+// The model is not passed to HiGHS yet, so the input can be changed before
+// invoking [instance.pass]. The caller must close the instance.
+// Constraints without terms are passed as rows if keepEmptyConstraints is
+// true. Otherwise, if one is violated, no instance is created and the error
+// carries the infeasible solution reporting the violated constraints, like
+// the solver of [NewSolver] returns; see [violatedSolution].
+func newInstance(
+	model mip.Model,
+	highsOptions Options,
+	options mip.SolveOptions,
+	start time.Time,
+	keepEmptyConstraints bool,
+) (*instance, error) {
+	fileOptions, err := validateOptions(options, highsOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error handling options in HiGHS solver: %w", err)
+	}
+
+	if err := Validate(model); err != nil {
+		return nil, err
+	}
+
+	if !keepEmptyConstraints {
+		violated := violatedEmptyConstraints(model, highsOptions.primalFeasibilityTolerance())
+		if len(violated) > 0 {
+			return nil, &emptyConstraintsError{solution: &highsSolution{
+				violatedConstraints: violated,
+				runtime:             time.Since(start),
+				solutionStatus:      infeasible,
+			}}
+		}
+	}
+
+	if len(model.Vars()) == 0 {
+		return nil, errNoVars
+	}
+
+	ptr := C.Highs_create()
+	if ptr == nil {
+		return nil, errors.New("highs failed creating an instance")
+	}
+
+	solver := &solverHighs{
+		model:                model,
+		options:              highsOptions,
+		keepEmptyConstraints: keepEmptyConstraints,
+	}
+	input := solver.newHighsInput(ptr, start, nil)
+	if err := handleOptions(ptr, *input, options, highsOptions, fileOptions); err != nil {
+		C.Highs_destroy(ptr)
+		return nil, fmt.Errorf("error handling options in HiGHS solver: %w", err)
+	}
+
+	return &instance{
+		ptr:                 ptr,
+		input:               input,
+		model:               model,
+		convexityCheckLimit: highsOptions.convexityCheckLimit(),
+		deadline:            start.Add(options.Duration),
+	}, nil
+}
+
+// emptyConstraintsError is returned by [newInstance] if constraints without
+// terms are violated, so the model is infeasible.
+type emptyConstraintsError struct {
+	solution *highsSolution
+}
+
+func (e *emptyConstraintsError) Error() string {
+	return fmt.Sprintf(
+		"highs: model is infeasible, %d constraints without terms are violated",
+		len(e.solution.violatedConstraints),
+	)
+}
+
+// violatedSolution returns the infeasible solution of an error of
+// [newInstance] for violated constraints without terms, nil for other
+// errors.
+func violatedSolution(err error) *highsSolution {
+	var emptyConstraints *emptyConstraintsError
+	if errors.As(err, &emptyConstraints) {
+		return emptyConstraints.solution
+	}
+
+	return nil
+}
+
+// dropObjective removes the objective of the model from the input, for
+// algorithms that set their own linear objectives with
+// [instance.changeCosts]. It must be invoked before [instance.pass].
+func (i *instance) dropObjective() {
+	for k := range i.input.columnCosts {
+		i.input.columnCosts[k] = 0
+	}
+	i.input.numQuadraticNonZeros = 0
+	i.input.isQuadraticProblem = false
+	i.input.offset = 0
+}
+
+// close frees the HiGHS instance.
+func (i *instance) close() {
+	C.Highs_destroy(i.ptr)
+}
+
+// pass passes the input to HiGHS. A quadratic objective must be convex, and
+// the model continuous.
+func (i *instance) pass() error {
+	if i.input.isQuadraticProblem {
+		if i.input.isIntegerProblem {
+			return errInstanceMiqp
+		}
+		if err := checkConvexity(i.model, i.convexityCheckLimit, i.deadline); err != nil {
+			return err
+		}
+	}
+
+	if err := passModel(i.ptr, i.input); err != nil {
+		return err
+	}
+
+	if i.input.modelFile != "" {
+		return writeModel(i.ptr, i.input.modelFile)
+	}
+
+	return nil
+}
+
+// run solves the model and returns the solution of all columns and rows.
+// The option deviations are read on the first run only; later runs only
+// change the time limit.
+func (i *instance) run() (*highsSolution, error) {
+	solution, err := runModel(i.ptr, i.input)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.optionDeviations == nil {
+		i.optionDeviations = readOptionDeviations(i.ptr)
+	}
+	solution.optionDeviations = i.optionDeviations
+
+	return solution, nil
+}
+
+// setDeadline sets the time limit of the next run to the time left until
+// the deadline. It returns false if there is no time left.
+func (i *instance) setDeadline(deadline time.Time) (bool, error) {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return false, nil
+	}
+
+	return true, setDoubleOption(i.ptr, "time_limit", remaining.Seconds())
+}
+
+// changeColumnBounds changes the bounds of the columns at indices.
+// Infinite bounds are mapped to HiGHS infinity.
+func (i *instance) changeColumnBounds(
+	indices []int,
+	lower []float64,
+	upper []float64,
+) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	set := make([]C.HighsInt, len(indices))
+	lowerBounds := make([]C.double, len(indices))
+	upperBounds := make([]C.double, len(indices))
+	for k, index := range indices {
+		set[k] = C.HighsInt(index)
+		lowerBounds[k] = toHighsValue(lower[k], i.input.infinity)
+		upperBounds[k] = toHighsValue(upper[k], i.input.infinity)
+	}
+
+	status := C.Highs_changeColsBoundsBySet(
+		i.ptr,
+		C.HighsInt(len(set)),
+		&set[0],
+		&lowerBounds[0],
+		&upperBounds[0],
+	)
+	if status == C.kHighsStatusError {
+		return errChangingBounds
+	}
+
+	return nil
+}
+
+// changeRowBounds changes the bounds of the rows at indices. Infinite
+// bounds are mapped to HiGHS infinity.
+func (i *instance) changeRowBounds(
+	indices []int,
+	lower []float64,
+	upper []float64,
+) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	set := make([]C.HighsInt, len(indices))
+	lowerBounds := make([]C.double, len(indices))
+	upperBounds := make([]C.double, len(indices))
+	for k, index := range indices {
+		set[k] = C.HighsInt(index)
+		lowerBounds[k] = toHighsValue(lower[k], i.input.infinity)
+		upperBounds[k] = toHighsValue(upper[k], i.input.infinity)
+	}
+
+	status := C.Highs_changeRowsBoundsBySet(
+		i.ptr,
+		C.HighsInt(len(set)),
+		&set[0],
+		&lowerBounds[0],
+		&upperBounds[0],
+	)
+	if status == C.kHighsStatusError {
+		return errChangingRowBounds
+	}
+
+	return nil
+}
+
+// changeCosts sets the costs of all columns.
+func (i *instance) changeCosts(costs []float64) error {
+	for k, cost := range costs {
+		i.input.columnCosts[k] = C.double(cost)
+	}
+
+	status := C.Highs_changeColsCostByRange(
+		i.ptr,
+		0,
+		C.HighsInt(len(costs)-1),
+		&i.input.columnCosts[0],
+	)
+	if status == C.kHighsStatusError {
+		return errChangingCosts
+	}
+
+	return nil
+}
+
+// changeSense sets the objective sense.
+func (i *instance) changeSense(maximize bool) error {
+	i.input.sense = C.kHighsObjSenseMinimize
+	if maximize {
+		i.input.sense = C.kHighsObjSenseMaximize
+	}
+
+	if C.Highs_changeObjectiveSense(i.ptr, C.HighsInt(i.input.sense)) == C.kHighsStatusError {
+		return errChangingSense
+	}
+
+	return nil
+}
+
+// addRows adds rows to the model and returns the index of the first one.
+func (i *instance) addRows(rows []highsRow) (int, error) {
+	first := i.input.numRows
+	if len(rows) == 0 {
+		return first, nil
+	}
+
+	lower := make([]C.double, len(rows))
+	upper := make([]C.double, len(rows))
+	starts := make([]C.HighsInt, len(rows))
+	indices := make([]C.HighsInt, 0)
+	values := make([]C.double, 0)
+	for k, row := range rows {
+		lower[k] = toHighsValue(row.lower, i.input.infinity)
+		upper[k] = toHighsValue(row.upper, i.input.infinity)
+		starts[k] = C.HighsInt(len(indices))
+		for _, t := range row.terms {
+			indices = append(indices, C.HighsInt(t.index))
+			values = append(values, C.double(t.coefficient))
+		}
+	}
+
+	pIndices := (*C.HighsInt)(unsafe.Pointer(nil))
+	pValues := (*C.double)(unsafe.Pointer(nil))
+	if len(indices) > 0 {
+		pIndices = &indices[0]
+		pValues = &values[0]
+	}
+
+	status := C.Highs_addRows(
+		i.ptr,
+		C.HighsInt(len(rows)),
+		&lower[0],
+		&upper[0],
+		C.HighsInt(len(indices)),
+		&starts[0],
+		pIndices,
+		pValues,
+	)
+	if status == C.kHighsStatusError {
+		return 0, errAddingRows
+	}
+	i.input.numRows += len(rows)
+
+	return first, nil
+}
+
+// highsColumn is a column added to a model passed to HiGHS, with terms over
+// the rows of the model.
+type highsColumn struct {
+	cost  float64
+	lower float64
+	upper float64
+	terms []linearTerm
+}
+
+// addColumns adds continuous columns to the model and returns the index of
+// the first one.
+func (i *instance) addColumns(columns []highsColumn) (int, error) {
+	first := i.input.numColumns
+	if len(columns) == 0 {
+		return first, nil
+	}
+
+	costs := make([]C.double, len(columns))
+	lower := make([]C.double, len(columns))
+	upper := make([]C.double, len(columns))
+	starts := make([]C.HighsInt, len(columns))
+	indices := make([]C.HighsInt, 0)
+	values := make([]C.double, 0)
+	for k, column := range columns {
+		costs[k] = C.double(column.cost)
+		lower[k] = toHighsValue(column.lower, i.input.infinity)
+		upper[k] = toHighsValue(column.upper, i.input.infinity)
+		starts[k] = C.HighsInt(len(indices))
+		for _, t := range column.terms {
+			indices = append(indices, C.HighsInt(t.index))
+			values = append(values, C.double(t.coefficient))
+		}
+	}
+
+	pIndices := (*C.HighsInt)(unsafe.Pointer(nil))
+	pValues := (*C.double)(unsafe.Pointer(nil))
+	if len(indices) > 0 {
+		pIndices = &indices[0]
+		pValues = &values[0]
+	}
+
+	status := C.Highs_addCols(
+		i.ptr,
+		C.HighsInt(len(columns)),
+		&costs[0],
+		&lower[0],
+		&upper[0],
+		C.HighsInt(len(indices)),
+		&starts[0],
+		pIndices,
+		pValues,
+	)
+	if status == C.kHighsStatusError {
+		return 0, errAddingColumns
+	}
+
+	i.input.numColumns += len(columns)
+	i.input.columnCosts = append(i.input.columnCosts, costs...)
+	i.input.columnLowerBound = append(i.input.columnLowerBound, lower...)
+	i.input.columnUpperBound = append(i.input.columnUpperBound, upper...)
+	for range columns {
+		i.input.columnIntegrality = append(
+			i.input.columnIntegrality,
+			C.kHighsVarTypeContinuous,
+		)
+	}
+
+	return first, nil
+}
+
+// changeIntegrality sets the columns at indices to integer, or to
+// continuous if integer is false.
+func (i *instance) changeIntegrality(indices []int, integer bool) error {
+	integrality := C.int(C.kHighsVarTypeContinuous)
+	if integer {
+		integrality = C.kHighsVarTypeInteger
+	}
+	for _, index := range indices {
+		i.input.columnIntegrality[index] = integrality
+	}
+
+	status := C.Highs_changeColsIntegralityByRange(
+		i.ptr,
+		0,
+		C.HighsInt(i.input.numColumns-1),
+		&i.input.columnIntegrality[0],
+	)
+	if status == C.kHighsStatusError {
+		return errChangingIntegrality
+	}
+
+	i.input.isIntegerProblem = false
+	for _, t := range i.input.columnIntegrality {
+		if t != C.kHighsVarTypeContinuous {
+			i.input.isIntegerProblem = true
+		}
+	}
+
+	return nil
+}
+
+// setSolution passes the values of the columns as a starting point for the
+// next run.
+func (i *instance) setSolution(values []float64) error {
+	columnValues := make([]C.double, len(values))
+	for k, value := range values {
+		columnValues[k] = C.double(value)
+	}
+
+	status := C.Highs_setSolution(
+		i.ptr,
+		&columnValues[0],
+		(*C.double)(unsafe.Pointer(nil)),
+		(*C.double)(unsafe.Pointer(nil)),
+		(*C.double)(unsafe.Pointer(nil)),
+	)
+	if status == C.kHighsStatusError {
+		return errSettingSolution
+	}
+
+	return nil
+}
+
+// dualRay returns a dual ray of the rows of an infeasible LP, which
+// certifies its infeasibility, and false if HiGHS found none.
+func (i *instance) dualRay() ([]float64, bool, error) {
+	if i.input.numRows == 0 {
+		return nil, false, nil
+	}
+
+	values := make([]C.double, i.input.numRows)
+	hasRay := C.HighsInt(0)
+	if C.Highs_getDualRay(i.ptr, &hasRay, &values[0]) == C.kHighsStatusError {
+		return nil, false, errGettingDualRay
+	}
+	if hasRay == 0 {
+		return nil, false, nil
+	}
+
+	ray := make([]float64, len(values))
+	for k, value := range values {
+		ray[k] = float64(value)
+	}
+
+	return ray, true, nil
+}
+
+// bound returns the best bound on the optimal value found by the last run
+// that returned the solution: the dual bound of a MIP and the objective
+// value of an LP.
+func (i *instance) bound(solution *highsSolution) (float64, error) {
+	if !i.input.isIntegerProblem {
+		return solution.objectiveValue, nil
+	}
+
+	info := C.CString("mip_dual_bound")
+	defer C.free(unsafe.Pointer(info))
+	value := C.double(0)
+	if C.Highs_getDoubleInfoValue(i.ptr, info, &value) != C.kHighsStatusOk {
+		return 0, errGettingInfo
+	}
+
+	return float64(value), nil
+}
+
+// hasFeasibleSolution reports whether the last run found a feasible
+// solution, even if it did not prove it optimal.
+func (i *instance) hasFeasibleSolution() (bool, error) {
+	info := C.CString("primal_solution_status")
+	defer C.free(unsafe.Pointer(info))
+	value := C.HighsInt(0)
+	if C.Highs_getIntInfoValue(i.ptr, info, &value) != C.kHighsStatusOk {
+		return false, errGettingInfo
+	}
+
+	return value == C.kHighsSolutionStatusFeasible, nil
+}
+
+// solve minimizes the costs until the deadline and returns the solution
+// restricted to the model.
+func (i *instance) solve(costs []float64, deadline time.Time) (*highsSolution, error) {
+	if err := i.changeCosts(costs); err != nil {
+		return nil, err
+	}
+	if err := i.changeSense(false); err != nil {
+		return nil, err
+	}
+
+	hasTime, err := i.setDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTime {
+		return &highsSolution{solutionStatus: timeLimit}, nil
+	}
+
+	solution, err := i.run()
+	if err != nil {
+		return nil, err
+	}
+	solution.trim(i.input)
+	solution.runtime = time.Since(i.input.start)
+
+	return solution, nil
+}
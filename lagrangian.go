@@ -0,0 +1,322 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// SubgradientOptions are the settings of the multiplier updates of a
+// [LagrangianSolver]. The step of an iteration with Lagrangian value L and
+// subgradient g is Scale·|target - L|/‖g‖², where the target is the
+// objective value of the best primal solution found by the repair, or
+// Target if there is none.
+type SubgradientOptions struct {
+	// Iterations is the maximum number of relaxations solved.
+	Iterations int
+	// Scale is the initial scale of the steps, usually in (0, 2].
+	Scale float64
+	// Patience is the number of iterations without an improvement of the
+	// best bound after which the scale is halved.
+	Patience int
+	// MinimumScale stops the iterations once the scale drops below it.
+	MinimumScale float64
+	// Target is an estimate of the optimal value of the model. If it is
+	// NaN, the best bound moved by 10% of its magnitude, at least by 1, is
+	// used instead.
+	Target float64
+}
+
+// DefaultSubgradientOptions returns 100 iterations with a scale of 2 that
+// is halved after 5 iterations without improvement, down to 1e-4, and no
+// target.
+func DefaultSubgradientOptions() SubgradientOptions {
+	return SubgradientOptions{
+		Iterations:   100,
+		Scale:        2,
+		Patience:     5,
+		MinimumScale: 1e-4,
+		Target:       math.NaN(),
+	}
+}
+
+// Repair returns a feasible solution of the model built from the solution
+// of a relaxation, for example by fixing the variables it sets and solving
+// the model. It returns nil, or a solution without values, if it finds
+// none. The objective value of the solution is trusted.
+type Repair func(relaxed Solution) mip.Solution
+
+// LagrangianResult is the result of a [LagrangianSolver].
+type LagrangianResult struct {
+	// Bound is the best Lagrangian bound, a lower bound on the optimal
+	// value of a minimization and an upper bound on that of a
+	// maximization. It is ∓Inf if no relaxation was solved to optimality.
+	Bound float64
+	// Multipliers are the multipliers of the dualized constraints that
+	// gave the best bound.
+	Multipliers map[mip.Constraint]float64
+	// Relaxed is the solution of the relaxation that gave the best bound.
+	// Its objective value is the Lagrangian value, including the penalties
+	// of the dualized constraints. It is the solution of the last
+	// relaxation if none was solved to optimality.
+	Relaxed Solution
+	// Primal is the best solution found by the repair. It is nil if there
+	// is no repair or it found no solution.
+	Primal mip.Solution
+	// Gap is the gap between the objective value of the primal solution
+	// and the bound relative to the former, +Inf without primal solution.
+	Gap float64
+	// Iterations is the number of relaxations solved.
+	Iterations int
+}
+
+// LagrangianSolver computes a Lagrangian bound of a model by subgradient
+// optimization. The dualized constraints are removed from the model and
+// their violations penalized in the objective, weighted by multipliers:
+// non-negative ones for inequalities and free ones for equalities. Every
+// iteration solves the relaxation with HiGHS and moves the multipliers
+// along the violations of the dualized constraints.
+type LagrangianSolver struct {
+	model       mip.Model
+	options     Options
+	subgradient SubgradientOptions
+	constraints []mip.Constraint
+	repair      Repair
+}
+
+// NewLagrangianSolver creates a solver for the model that dualizes the
+// constraints, with the typed options applied to every relaxation.
+func NewLagrangianSolver(
+	model mip.Model,
+	options Options,
+	subgradient SubgradientOptions,
+	constraints ...mip.Constraint,
+) *LagrangianSolver {
+	return &LagrangianSolver{
+		model:       model,
+		options:     options,
+		subgradient: subgradient,
+		constraints: append([]mip.Constraint(nil), constraints...),
+	}
+}
+
+// SetRepair sets the heuristic run on the solution of every relaxation to
+// find primal solutions.
+func (s *LagrangianSolver) SetRepair(repair Repair) {
+	s.repair = repair
+}
+
+// dualized is a dualized constraint s·(a·x - b) <= 0, with s = -1 for
+// greater-than-or-equal constraints and s = 1 otherwise.
+type dualized struct {
+	terms    []linearTerm
+	rhs      float64
+	sign     float64
+	equality bool
+}
+
+// violation returns s·(a·x - b) for the values.
+func (d dualized) violation(values []float64) float64 {
+	activity := 0.0
+	for _, t := range d.terms {
+		activity += t.coefficient * values[t.index]
+	}
+
+	return d.sign * (activity - d.rhs)
+}
+
+// Solve iterates until the iterations of the subgradient options are used
+// up, the scale drops below its minimum, the multipliers satisfy the
+// optimality conditions, the bound meets the primal solution within the
+// MIP gap of the options or the duration of the options is used up. It
+// stops early if a relaxation is not solved to optimality. The bound is
+// the MIP dual bound of a relaxation with integer variables.
+func (s *LagrangianSolver) Solve(options mip.SolveOptions) (LagrangianResult, error) {
+	start := time.Now()
+	sign := 1.0
+	if s.model.Objective().IsMaximize() {
+		sign = -1.0
+	}
+	result := LagrangianResult{
+		Bound:       -sign * math.Inf(1),
+		Multipliers: make(map[mip.Constraint]float64, len(s.constraints)),
+		Relaxed:     &highsSolution{solutionStatus: timeLimit},
+		Gap:         math.Inf(1),
+	}
+	if len(s.constraints) == 0 {
+		return result, errors.New("highs: Lagrangian relaxation needs constraints to dualize")
+	}
+	if s.subgradient.Scale <= 0 || s.subgradient.Patience <= 0 {
+		return result, errors.New("highs: subgradient options need a positive scale and patience")
+	}
+
+	relaxations, err := newInstance(s.model, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		result.Relaxed = violated
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	defer relaxations.close()
+
+	if err := relaxations.pass(); err != nil {
+		return result, err
+	}
+
+	input := relaxations.input
+	constraints := make([]dualized, len(s.constraints))
+	rows := make([]int, 0, len(s.constraints))
+	for k, c := range s.constraints {
+		constraints[k] = dualized{terms: linearTerms(c.Terms()), rhs: c.RightHandSide(), sign: 1}
+		switch c.Sense() {
+		case mip.GreaterThanOrEqual:
+			constraints[k].sign = -1
+		case mip.Equal:
+			constraints[k].equality = true
+		}
+		if row, ok := input.constraintRows[c]; ok {
+			rows = append(rows, row)
+		}
+	}
+	lower := make([]float64, len(rows))
+	upper := make([]float64, len(rows))
+	for k := range rows {
+		lower[k], upper[k] = math.Inf(-1), math.Inf(1)
+	}
+	if err := relaxations.changeRowBounds(rows, lower, upper); err != nil {
+		return result, err
+	}
+
+	costs := make([]float64, input.numColumns)
+	for j, cost := range input.columnCosts {
+		costs[j] = float64(cost)
+	}
+
+	deadline := start.Add(options.Duration)
+	multipliers := make([]float64, len(constraints))
+	scale := s.subgradient.Scale
+	stalled := 0
+	for result.Iterations < s.subgradient.Iterations && scale >= s.subgradient.MinimumScale {
+		// The relaxation optimizes c·x + sign·Σ u_k s_k (a_k·x - b_k).
+		penalized := append([]float64(nil), costs...)
+		constant := 0.0
+		for k, c := range constraints {
+			weight := sign * multipliers[k] * c.sign
+			for _, t := range c.terms {
+				penalized[t.index] += weight * t.coefficient
+			}
+			constant -= weight * c.rhs
+		}
+		if err := relaxations.changeCosts(penalized); err != nil {
+			return result, err
+		}
+
+		hasTime, err := relaxations.setDeadline(deadline)
+		if err != nil {
+			return result, err
+		}
+		if !hasTime {
+			break
+		}
+
+		solution, err := relaxations.run()
+		if err != nil {
+			return result, err
+		}
+		result.Iterations++
+		if !solution.IsOptimal() {
+			if math.IsInf(result.Bound, 0) {
+				solution.trim(input)
+				solution.runtime = time.Since(start)
+				result.Relaxed = solution
+			}
+			break
+		}
+
+		bound, err := relaxations.bound(solution)
+		if err != nil {
+			return result, err
+		}
+		bound += constant
+		value := solution.objectiveValue + constant
+		solution.objectiveValue = value
+		solution.trim(input)
+		solution.runtime = time.Since(start)
+
+		if sign*bound > sign*result.Bound {
+			result.Bound = bound
+			result.Relaxed = solution
+			for k, c := range s.constraints {
+				result.Multipliers[c] = multipliers[k]
+			}
+			stalled = 0
+		} else if stalled++; stalled >= s.subgradient.Patience {
+			scale /= 2
+			stalled = 0
+		}
+
+		if s.repair != nil {
+			primal := s.repair(solution)
+			if primal != nil && primal.HasValues() &&
+				(result.Primal == nil ||
+					sign*primal.ObjectiveValue() < sign*result.Primal.ObjectiveValue()) {
+				result.Primal = primal
+			}
+		}
+		if result.Primal != nil {
+			incumbent := result.Primal.ObjectiveValue()
+			result.Gap = sign * (incumbent - result.Bound) / math.Max(math.Abs(incumbent), 1e-10)
+			if gapClosed(sign*incumbent, sign*result.Bound, options) {
+				break
+			}
+		}
+
+		// The projected subgradient leaves multipliers of satisfied
+		// inequalities at 0.
+		subgradient := make([]float64, len(constraints))
+		norm := 0.0
+		for k, c := range constraints {
+			subgradient[k] = c.violation(solution.values)
+			if !c.equality && multipliers[k] <= 0 && subgradient[k] < 0 {
+				subgradient[k] = 0
+			}
+			norm += subgradient[k] * subgradient[k]
+		}
+		if norm == 0 {
+			break
+		}
+
+		target := s.target(result)
+		step := scale * math.Abs(target-value) / norm
+		for k, c := range constraints {
+			multipliers[k] += step * subgradient[k]
+			if !c.equality {
+				multipliers[k] = math.Max(0, multipliers[k])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// target returns the estimate of the optimal value of the step sizes.
+func (s *LagrangianSolver) target(result LagrangianResult) float64 {
+	if result.Primal != nil {
+		return result.Primal.ObjectiveValue()
+	}
+	if !math.IsNaN(s.subgradient.Target) {
+		return s.subgradient.Target
+	}
+
+	sign := 1.0
+	if s.model.Objective().IsMaximize() {
+		sign = -1.0
+	}
+
+	return result.Bound + sign*math.Max(1, 0.1*math.Abs(result.Bound))
+}
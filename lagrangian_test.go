@@ -0,0 +1,175 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// assignment is a generalized assignment problem: assign every job to a
+// machine at minimum cost without exceeding the capacities of the
+// machines.
+type assignment struct {
+	// costs[m][j] and weights[m][j] are the cost and weight of job j on
+	// machine m.
+	costs      [][]float64
+	weights    [][]float64
+	capacities []float64
+}
+
+var jobs = assignment{
+	costs: [][]float64{
+		{2, 3, 4, 5},
+		{6, 5, 7, 4},
+	},
+	weights: [][]float64{
+		{4, 3, 5, 2},
+		{3, 4, 2, 5},
+	},
+	capacities: []float64{7, 8},
+}
+
+// model returns the model with x[m][j] assigning job j to machine m, the
+// capacity constraints and, if fixed is not nil, jobs j with fixed[j] >= 0
+// assigned to machine fixed[j].
+func (a assignment) model(fixed []int) (mip.Model, [][]mip.Var, []mip.Constraint) {
+	model := mip.NewModel()
+	x := make([][]mip.Var, len(a.costs))
+	capacities := make([]mip.Constraint, len(a.costs))
+	for m := range a.costs {
+		x[m] = make([]mip.Var, len(a.costs[m]))
+		capacities[m] = model.NewConstraint(mip.LessThanOrEqual, a.capacities[m])
+		for j, cost := range a.costs[m] {
+			x[m][j] = model.NewBool()
+			model.Objective().NewTerm(cost, x[m][j])
+			capacities[m].NewTerm(a.weights[m][j], x[m][j])
+		}
+	}
+	for j := range a.costs[0] {
+		assigned := model.NewConstraint(mip.Equal, 1)
+		for m := range a.costs {
+			assigned.NewTerm(1, x[m][j])
+		}
+		if fixed != nil && fixed[j] >= 0 {
+			model.NewConstraint(mip.Equal, 1).NewTerm(1, x[fixed[j]][j])
+		}
+	}
+
+	return model, x, capacities
+}
+
+// bruteForce returns the optimal cost by enumeration.
+func (a assignment) bruteForce() float64 {
+	best := math.Inf(1)
+	n := len(a.costs[0])
+	machines := make([]int, n)
+	var enumerate func(j int)
+	enumerate = func(j int) {
+		if j == n {
+			cost := 0.0
+			load := make([]float64, len(a.costs))
+			for j, m := range machines {
+				cost += a.costs[m][j]
+				load[m] += a.weights[m][j]
+			}
+			for m, l := range load {
+				if l > a.capacities[m] {
+					return
+				}
+			}
+			best = math.Min(best, cost)
+			return
+		}
+		for m := range a.costs {
+			machines[j] = m
+			enumerate(j + 1)
+		}
+	}
+	enumerate(0)
+
+	return best
+}
+
+func TestLagrangian(t *testing.T) {
+	want := jobs.bruteForce()
+
+	model, x, capacities := jobs.model(nil)
+
+	// The repair keeps the jobs of the relaxation on their machine as
+	// long as they fit and assigns the others optimally.
+	repair := func(relaxed highs.Solution) mip.Solution {
+		fixed := make([]int, len(jobs.costs[0]))
+		load := make([]float64, len(jobs.costs))
+		for j := range fixed {
+			fixed[j] = -1
+			for m := range jobs.costs {
+				if relaxed.Value(x[m][j]) > 0.5 &&
+					load[m]+jobs.weights[m][j] <= jobs.capacities[m] {
+					fixed[j] = m
+					load[m] += jobs.weights[m][j]
+				}
+			}
+		}
+		repaired, _, _ := jobs.model(fixed)
+		solution, err := highs.NewSolver(repaired).Solve(defaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return solution
+	}
+
+	solver := highs.NewLagrangianSolver(
+		model,
+		highs.DefaultOptions(),
+		highs.DefaultSubgradientOptions(),
+		capacities...,
+	)
+	solver.SetRepair(repair)
+	result, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without multipliers every job goes to its cheapest machine.
+	unconstrained := 0.0
+	for j := range jobs.costs[0] {
+		unconstrained += math.Min(jobs.costs[0][j], jobs.costs[1][j])
+	}
+	if result.Bound > want+1e-6 || result.Bound < unconstrained-1e-6 {
+		t.Errorf("got bound %v, want within [%v, %v]", result.Bound, unconstrained, want)
+	}
+	if result.Iterations == 0 || result.Relaxed == nil || !result.Relaxed.IsOptimal() {
+		t.Fatal("expected an optimal relaxation")
+	}
+	for c, multiplier := range result.Multipliers {
+		if multiplier < 0 {
+			t.Errorf("got multiplier %v of %v, want non-negative", multiplier, c)
+		}
+	}
+
+	if result.Primal == nil {
+		t.Fatal("expected a primal solution")
+	}
+	if result.Primal.ObjectiveValue() < want-1e-6 {
+		t.Errorf("got primal value %v, below the optimum %v", result.Primal.ObjectiveValue(), want)
+	}
+	if result.Gap < -1e-6 {
+		t.Errorf("got gap %v, want non-negative", result.Gap)
+	}
+}
+
+func TestLagrangianNoConstraints(t *testing.T) {
+	model, _, _ := jobs.model(nil)
+	solver := highs.NewLagrangianSolver(
+		model,
+		highs.DefaultOptions(),
+		highs.DefaultSubgradientOptions(),
+	)
+	if _, err := solver.Solve(defaultOptions()); err == nil {
+		t.Error("expected an error without dualized constraints")
+	}
+}
@@ -0,0 +1,211 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// LazyConstraint is a constraint a·x sense rhs over the variables of a
+// model that is only added to the model once a solution violates it.
+type LazyConstraint struct {
+	name  string
+	sense mip.Sense
+	rhs   float64
+	terms mip.Terms
+}
+
+// NewLazyConstraint creates the constraint a·x sense rhs. Add terms with
+// [LazyConstraint.NewTerm].
+func NewLazyConstraint(sense mip.Sense, rhs float64) *LazyConstraint {
+	return &LazyConstraint{
+		sense: sense,
+		rhs:   rhs,
+		terms: make(mip.Terms, 0),
+	}
+}
+
+// NewTerm adds a term to the constraint. Invoking it multiple times for the
+// same variable sums the coefficients.
+func (c *LazyConstraint) NewTerm(coefficient float64, variable mip.Var) mip.Term {
+	if math.IsNaN(coefficient) {
+		panic("lazy constraint term coefficient is NaN")
+	}
+
+	t := term{coefficient: coefficient, variable: variable}
+	c.terms = append(c.terms, t)
+
+	return t
+}
+
+// Terms returns the terms of the constraint, with the coefficients of the
+// terms of the same variable summed and zero terms dropped.
+func (c *LazyConstraint) Terms() mip.Terms {
+	return uniqueTerms(c.terms)
+}
+
+// Sense returns the sense of the constraint.
+func (c *LazyConstraint) Sense() mip.Sense {
+	return c.sense
+}
+
+// RightHandSide returns the right-hand side of the constraint.
+func (c *LazyConstraint) RightHandSide() float64 {
+	return c.rhs
+}
+
+// Name returns the name of the constraint.
+func (c *LazyConstraint) Name() string {
+	return c.name
+}
+
+// SetName sets the name of the constraint.
+func (c *LazyConstraint) SetName(name string) {
+	c.name = name
+}
+
+// Violation returns by how much the values of the solution violate the
+// constraint, 0 if they satisfy it.
+func (c *LazyConstraint) Violation(solution mip.Solution) float64 {
+	activity := 0.0
+	for _, t := range c.terms {
+		activity += t.Coefficient() * solution.Value(t.Var())
+	}
+
+	switch c.sense {
+	case mip.LessThanOrEqual:
+		return math.Max(0, activity-c.rhs)
+	case mip.GreaterThanOrEqual:
+		return math.Max(0, c.rhs-activity)
+	default:
+		return math.Abs(activity - c.rhs)
+	}
+}
+
+func (c *LazyConstraint) row() highsRow {
+	row := highsRow{terms: linearTerms(c.Terms()), lower: c.rhs, upper: c.rhs}
+	switch c.sense {
+	case mip.LessThanOrEqual:
+		row.lower = math.Inf(-1)
+	case mip.GreaterThanOrEqual:
+		row.upper = math.Inf(1)
+	}
+
+	return row
+}
+
+// Separator returns constraints violated by the values of a solution, for
+// example the subtour elimination constraints of the subtours of a routing
+// solution. It returns none if the solution is feasible. Constraints that
+// the solution does not violate are ignored.
+type Separator func(solution Solution) []*LazyConstraint
+
+// LazyResult is the result of a [LazySolver].
+type LazyResult struct {
+	// Solution is the solution of the last round. If it is not optimal the
+	// values may violate lazy constraints not added yet. If the duration
+	// is used up after a round, it is a time out with the values of that
+	// round, which violate the lazy constraints added after it.
+	Solution Solution
+	// Rounds is the number of times the model was solved.
+	Rounds int
+	// Cuts is the number of lazy constraints added to the model.
+	Cuts int
+	// Constraints are the lazy constraints added to the model, in the
+	// order they were added.
+	Constraints []*LazyConstraint
+}
+
+// LazySolver solves a model with lazy constraints: constraints that are too
+// many to add upfront, such as subtour elimination constraints. It solves
+// the model, asks a [Separator] for the constraints the solution violates,
+// adds them to the HiGHS instance and solves again, until the separator
+// returns no violated constraint.
+type LazySolver struct {
+	model     mip.Model
+	options   Options
+	separator Separator
+}
+
+// NewLazySolver creates a solver for the model with lazy constraints found
+// by the separator, with the typed options applied to every solve.
+func NewLazySolver(model mip.Model, options Options, separator Separator) *LazySolver {
+	return &LazySolver{
+		model:     model,
+		options:   options,
+		separator: separator,
+	}
+}
+
+// Solve solves the model until no lazy constraint is violated or the
+// duration of the options is used up, which the result reports as a time
+// out. A constraint is violated if its violation exceeds the primal
+// feasibility tolerance of the typed options.
+func (s *LazySolver) Solve(options mip.SolveOptions) (LazyResult, error) {
+	start := time.Now()
+	result := LazyResult{Constraints: make([]*LazyConstraint, 0)}
+
+	lazy, err := newInstance(s.model, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		result.Solution = violated
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	defer lazy.close()
+
+	if err := lazy.pass(); err != nil {
+		return result, err
+	}
+
+	deadline := start.Add(options.Duration)
+	last := &highsSolution{}
+	for {
+		hasTime, err := lazy.setDeadline(deadline)
+		if err != nil {
+			return result, err
+		}
+		if !hasTime {
+			// The values of the last round are kept, even though they
+			// violate the lazy constraints added after it.
+			last.solutionStatus = timeLimit
+			last.suboptimal = last.values != nil
+			last.runtime = time.Since(start)
+			result.Solution = last
+			return result, nil
+		}
+
+		solution, err := lazy.run()
+		if err != nil {
+			return result, err
+		}
+		solution.trim(lazy.input)
+		solution.runtime = time.Since(start)
+		result.Solution = solution
+		result.Rounds++
+		last = solution
+		if !solution.IsOptimal() {
+			return result, nil
+		}
+
+		rows := make([]highsRow, 0)
+		for _, c := range s.separator(solution) {
+			if c.Violation(solution) > s.options.primalFeasibilityTolerance() {
+				rows = append(rows, c.row())
+				result.Constraints = append(result.Constraints, c)
+			}
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+
+		if _, err := lazy.addRows(rows); err != nil {
+			return result, err
+		}
+		result.Cuts += len(rows)
+	}
+}
@@ -0,0 +1,198 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// tspPoints are two clusters of three points, so the degree constraints
+// alone give two subtours.
+var tspPoints = [][2]float64{{0, 0}, {1, 0}, {0, 1}, {10, 0}, {11, 0}, {10, 1}}
+
+func tspDistance(i, j int) float64 {
+	return math.Hypot(tspPoints[i][0]-tspPoints[j][0], tspPoints[i][1]-tspPoints[j][1])
+}
+
+// tspBruteForce returns the length of the shortest tour by enumeration.
+func tspBruteForce() float64 {
+	n := len(tspPoints)
+	best := math.Inf(1)
+	tour := []int{0}
+	used := make([]bool, n)
+	used[0] = true
+	var visit func(length float64)
+	visit = func(length float64) {
+		last := tour[len(tour)-1]
+		if len(tour) == n {
+			best = math.Min(best, length+tspDistance(last, 0))
+			return
+		}
+		for next := 1; next < n; next++ {
+			if !used[next] {
+				used[next] = true
+				tour = append(tour, next)
+				visit(length + tspDistance(last, next))
+				tour = tour[:len(tour)-1]
+				used[next] = false
+			}
+		}
+	}
+	visit(0)
+
+	return best
+}
+
+// tspModel returns the degree-constrained model of a symmetric TSP with a
+// binary per edge.
+func tspModel() (mip.Model, map[[2]int]mip.Var) {
+	n := len(tspPoints)
+	model := mip.NewModel()
+	edges := make(map[[2]int]mip.Var)
+	degrees := make([]mip.Constraint, n)
+	for i := range degrees {
+		degrees[i] = model.NewConstraint(mip.Equal, 2)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			x := model.NewBool()
+			edges[[2]int{i, j}] = x
+			model.Objective().NewTerm(tspDistance(i, j), x)
+			degrees[i].NewTerm(1, x)
+			degrees[j].NewTerm(1, x)
+		}
+	}
+
+	return model, edges
+}
+
+// subtours returns a subtour elimination constraint for every connected
+// component of the edges used by the solution if there is more than one.
+func subtours(edges map[[2]int]mip.Var) highs.Separator {
+	return func(solution highs.Solution) []*highs.LazyConstraint {
+		n := len(tspPoints)
+		component := make([]int, n)
+		for i := range component {
+			component[i] = i
+		}
+		var find func(i int) int
+		find = func(i int) int {
+			if component[i] != i {
+				component[i] = find(component[i])
+			}
+			return component[i]
+		}
+		for edge, x := range edges {
+			if solution.Value(x) > 0.5 {
+				component[find(edge[0])] = find(edge[1])
+			}
+		}
+
+		members := make(map[int][]int)
+		for i := 0; i < n; i++ {
+			members[find(i)] = append(members[find(i)], i)
+		}
+		if len(members) == 1 {
+			return nil
+		}
+
+		cuts := make([]*highs.LazyConstraint, 0)
+		for _, set := range members {
+			// Σ_{i,j in set} x_ij <= |set| - 1
+			cut := highs.NewLazyConstraint(mip.LessThanOrEqual, float64(len(set)-1))
+			for a := 0; a < len(set); a++ {
+				for b := a + 1; b < len(set); b++ {
+					cut.NewTerm(1, edges[[2]int{set[a], set[b]}])
+				}
+			}
+			cuts = append(cuts, cut)
+		}
+
+		return cuts
+	}
+}
+
+func TestLazySolver(t *testing.T) {
+	model, edges := tspModel()
+	solver := highs.NewLazySolver(model, highs.DefaultOptions(), subtours(edges))
+
+	result, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	want := tspBruteForce()
+	if math.Abs(result.Solution.ObjectiveValue()-want) > 1e-6 {
+		t.Errorf("got tour length %v, want %v", result.Solution.ObjectiveValue(), want)
+	}
+
+	if result.Rounds < 2 {
+		t.Errorf("got %d rounds, want at least 2", result.Rounds)
+	}
+
+	if result.Cuts == 0 || result.Cuts != len(result.Constraints) {
+		t.Errorf("got %d cuts and %d constraints, want the same non-zero number",
+			result.Cuts, len(result.Constraints))
+	}
+
+	if cuts := subtours(edges)(result.Solution); len(cuts) != 0 {
+		t.Errorf("solution has %d subtours", len(cuts))
+	}
+}
+
+func TestLazySolverTimeLimit(t *testing.T) {
+	model, edges := tspModel()
+	solver := highs.NewLazySolver(model, highs.DefaultOptions(), subtours(edges))
+
+	options := defaultOptions()
+	options.Duration = time.Nanosecond
+	result, err := solver.Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Solution.IsTimeOut() {
+		t.Error("expected time out")
+	}
+}
+
+func TestLazySolverTimeLimitKeepsValues(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+
+	options := defaultOptions()
+	options.Duration = 100 * time.Millisecond
+	// The separator uses up the duration, so no second round is solved.
+	separator := func(highs.Solution) []*highs.LazyConstraint {
+		time.Sleep(options.Duration)
+		c := highs.NewLazyConstraint(mip.LessThanOrEqual, 5)
+		c.NewTerm(1, x)
+		return []*highs.LazyConstraint{c}
+	}
+
+	result, err := highs.NewLazySolver(model, highs.DefaultOptions(), separator).
+		Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Solution.IsTimeOut() || !result.Solution.HasValues() {
+		t.Fatal("expected time out with values")
+	}
+
+	if result.Rounds != 1 || result.Solution.Value(x) != 10 {
+		t.Errorf("got %d rounds and x = %v, want 1 round and x = 10",
+			result.Rounds, result.Solution.Value(x))
+	}
+}
@@ -0,0 +1,158 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// LexicographicStage is an objective of a lexicographic solve. Once the
+// stage is solved, later stages may degrade its optimal value z by at most
+// max(AbsoluteTolerance, RelativeTolerance·|z|).
+type LexicographicStage struct {
+	// Objective is the objective of the stage.
+	Objective *LinearObjective
+	// AbsoluteTolerance is the absolute degradation allowed.
+	AbsoluteTolerance float64
+	// RelativeTolerance is the degradation allowed relative to the optimal
+	// value of the stage.
+	RelativeTolerance float64
+}
+
+// StageResult is the result of a stage of a lexicographic solve.
+type StageResult struct {
+	// Stage is the stage that was solved.
+	Stage LexicographicStage
+	// Solution is the solution of the stage. Its objective value is the
+	// value of the objective of the stage.
+	Solution Solution
+	// Bound is the right-hand side of the constraint on the objective of
+	// the stage added for later stages, an upper bound when minimizing and
+	// a lower bound when maximizing. It is NaN if the stage was not solved
+	// to optimality, in which case no later stage is solved.
+	Bound float64
+}
+
+// LexicographicSolver solves a model for a ranked list of linear objectives.
+// Each stage optimizes its objective while keeping the objectives of the
+// earlier stages within their tolerances of the optimal values found. The
+// objective of the model is ignored.
+type LexicographicSolver struct {
+	model   mip.Model
+	options Options
+	stages  []LexicographicStage
+}
+
+// NewLexicographicSolver creates a lexicographic solver for the model with
+// the typed options applied to every stage.
+func NewLexicographicSolver(model mip.Model, options Options) *LexicographicSolver {
+	return &LexicographicSolver{
+		model:   model,
+		options: options,
+	}
+}
+
+// AddStage adds a stage, ranked after the stages added before.
+func (s *LexicographicSolver) AddStage(stage LexicographicStage) {
+	s.stages = append(s.stages, stage)
+}
+
+// Stages returns a copy slice of the stages.
+func (s *LexicographicSolver) Stages() []LexicographicStage {
+	return append([]LexicographicStage(nil), s.stages...)
+}
+
+// Solve solves the stages in order on a single HiGHS instance. After each
+// stage a constraint bounding its objective is added, and the next stage
+// starts from the solution of the previous one. The duration of the options
+// is the budget of all stages together. It returns a result for every stage
+// solved; the stages after one that is not solved to optimality are skipped.
+// Violated constraints without terms make the first stage infeasible.
+func (s *LexicographicSolver) Solve(options mip.SolveOptions) ([]StageResult, error) {
+	start := time.Now()
+	if len(s.stages) == 0 {
+		return nil, errors.New("highs: lexicographic solve needs at least one stage")
+	}
+
+	stages, err := newInstance(s.model, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		return []StageResult{{Stage: s.stages[0], Solution: violated, Bound: math.NaN()}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stages.close()
+
+	// The objectives of the stages replace the objective of the model.
+	input := stages.input
+	stages.dropObjective()
+	if err := stages.pass(); err != nil {
+		return nil, err
+	}
+
+	deadline := start.Add(options.Duration)
+	results := make([]StageResult, 0, len(s.stages))
+	var previous []float64
+	for _, stage := range s.stages {
+		objective := stage.Objective
+		if err := stages.changeCosts(objective.costs(input.numColumns)); err != nil {
+			return nil, err
+		}
+		if err := stages.changeSense(objective.IsMaximize()); err != nil {
+			return nil, err
+		}
+		if previous != nil && input.isIntegerProblem {
+			if err := stages.setSolution(previous); err != nil {
+				return nil, err
+			}
+		}
+
+		hasTime, err := stages.setDeadline(deadline)
+		if err != nil {
+			return nil, err
+		}
+		if !hasTime {
+			results = append(results, StageResult{
+				Stage:    stage,
+				Solution: &highsSolution{solutionStatus: timeLimit},
+				Bound:    math.NaN(),
+			})
+			break
+		}
+
+		solution, err := stages.run()
+		if err != nil {
+			return nil, err
+		}
+		previous = solution.values
+		solution.trim(input)
+		solution.runtime = time.Since(start)
+
+		result := StageResult{Stage: stage, Solution: solution, Bound: math.NaN()}
+		if !solution.IsOptimal() {
+			results = append(results, result)
+			break
+		}
+
+		z := solution.objectiveValue
+		tolerance := math.Max(stage.AbsoluteTolerance, stage.RelativeTolerance*math.Abs(z))
+		row := highsRow{terms: linearTerms(objective.Terms())}
+		if objective.IsMaximize() {
+			result.Bound = z - tolerance
+			row.lower, row.upper = result.Bound, math.Inf(1)
+		} else {
+			result.Bound = z + tolerance
+			row.lower, row.upper = math.Inf(-1), result.Bound
+		}
+		if _, err := stages.addRows([]highsRow{row}); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,109 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestLexicographic(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	y := model.NewInt(0, 10)
+	capacity := model.NewConstraint(mip.LessThanOrEqual, 10)
+	capacity.NewTerm(1, x)
+	capacity.NewTerm(1, y)
+
+	served := highs.NewLinearObjective(true)
+	served.NewTerm(1, x)
+	served.NewTerm(1, y)
+	cost := highs.NewLinearObjective(false)
+	cost.NewTerm(3, x)
+	cost.NewTerm(1, y)
+	preference := highs.NewLinearObjective(true)
+	preference.NewTerm(1, x)
+
+	solver := highs.NewLexicographicSolver(model, highs.DefaultOptions())
+	solver.AddStage(highs.LexicographicStage{Objective: served, AbsoluteTolerance: 2})
+	solver.AddStage(highs.LexicographicStage{Objective: cost, RelativeTolerance: 0.25})
+	solver.AddStage(highs.LexicographicStage{Objective: preference})
+
+	results, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	// served = 10 allows served >= 8, cost = 8 allows cost <= 10, which
+	// leaves x = 1 and y = 7.
+	want := []struct {
+		objective float64
+		bound     float64
+	}{
+		{objective: 10, bound: 8},
+		{objective: 8, bound: 10},
+		{objective: 1, bound: 1},
+	}
+	for i, result := range results {
+		if !result.Solution.IsOptimal() {
+			t.Fatalf("stage %d: expected optimal solution", i)
+		}
+		if math.Abs(result.Solution.ObjectiveValue()-want[i].objective) > 1e-6 {
+			t.Errorf("stage %d: got objective %v, want %v",
+				i, result.Solution.ObjectiveValue(), want[i].objective)
+		}
+		if math.Abs(result.Bound-want[i].bound) > 1e-6 {
+			t.Errorf("stage %d: got bound %v, want %v", i, result.Bound, want[i].bound)
+		}
+		got := result.Stage.Objective.Value(result.Solution)
+		if math.Abs(got-result.Solution.ObjectiveValue()) > 1e-6 {
+			t.Errorf("stage %d: got objective value %v, want %v",
+				i, got, result.Solution.ObjectiveValue())
+		}
+	}
+
+	last := results[2].Solution
+	if last.Value(x) != 1 || last.Value(y) != 7 {
+		t.Errorf("got x = %v, y = %v, want x = 1, y = 7", last.Value(x), last.Value(y))
+	}
+}
+
+func TestLexicographicInfeasible(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 2)
+	c.NewTerm(1, x)
+
+	first := highs.NewLinearObjective(false)
+	first.NewTerm(1, x)
+	second := highs.NewLinearObjective(true)
+	second.NewTerm(1, x)
+
+	solver := highs.NewLexicographicSolver(model, highs.DefaultOptions())
+	solver.AddStage(highs.LexicographicStage{Objective: first})
+	solver.AddStage(highs.LexicographicStage{Objective: second})
+
+	results, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+
+	if !results[0].Solution.IsInfeasible() {
+		t.Error("expected infeasible solution")
+	}
+
+	if !math.IsNaN(results[0].Bound) {
+		t.Errorf("got bound %v, want NaN", results[0].Bound)
+	}
+}
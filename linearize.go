@@ -0,0 +1,128 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+	"sort"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// binaryProduct is a term coefficient*x_i*x_j, i < j, of two binaries that
+// is replaced by a continuous column w in [0, 1].
+type binaryProduct struct {
+	i           int
+	j           int
+	coefficient float64
+}
+
+// binaryLinearization is the linear rewrite of a quadratic objective whose
+// terms only multiply binaries. HiGHS does not solve MIQPs, but a product
+// of binaries is linear in disguise:
+//
+//	x*x = x
+//	x*y = w,  w <= x, w <= y, w >= x + y - 1, w in [0, 1]
+//
+// The McCormick constraints on w are exact when x and y are binary, so the
+// rewritten MILP has the same optimal solutions and objective value.
+type binaryLinearization struct {
+	// costs are the coefficients of the terms x*x, added to the linear
+	// costs of x.
+	costs map[int]float64
+	// products are the terms x*y, in the order of their columns.
+	products []binaryProduct
+}
+
+// linearizeBinaryProducts returns the linearization of the quadratic
+// objective of the model if it has quadratic terms and all of them multiply
+// binaries, that is integer variables with bounds in [0, 1] that are not
+// semi-integer. It returns false otherwise.
+func linearizeBinaryProducts(model mip.Model) (*binaryLinearization, bool) {
+	terms := model.Objective().QuadraticTerms()
+	if len(terms) == 0 {
+		return nil, false
+	}
+
+	semi := semiVars(model)
+	for _, t := range terms {
+		if !isBinary(t.Var1(), semi) || !isBinary(t.Var2(), semi) {
+			return nil, false
+		}
+	}
+
+	linearization := &binaryLinearization{costs: make(map[int]float64)}
+	products := make(map[[2]int]float64)
+	for _, t := range terms {
+		i, j := t.Var1().Index(), t.Var2().Index()
+		if i == j {
+			linearization.costs[i] += t.Coefficient()
+			continue
+		}
+		products[[2]int{min(i, j), max(i, j)}] += t.Coefficient()
+	}
+
+	for pair, coefficient := range products {
+		if coefficient != 0 {
+			linearization.products = append(linearization.products, binaryProduct{
+				i:           pair[0],
+				j:           pair[1],
+				coefficient: coefficient,
+			})
+		}
+	}
+	sort.Slice(linearization.products, func(a, b int) bool {
+		pa, pb := linearization.products[a], linearization.products[b]
+		if pa.i != pb.i {
+			return pa.i < pb.i
+		}
+		return pa.j < pb.j
+	})
+
+	return linearization, true
+}
+
+// mccormickSuffixes are appended to the name of the column of a product for
+// the names of its three rows, in the order of [binaryLinearization.rows].
+var mccormickSuffixes = [3]string{"_le1", "_le2", "_ge"}
+
+// rows returns the McCormick constraints of the products, with the column
+// of product k at numColumns+k.
+func (l *binaryLinearization) rows(numColumns int) []highsRow {
+	rows := make([]highsRow, 0, 3*len(l.products))
+	for k, p := range l.products {
+		w := numColumns + k
+		rows = append(rows,
+			highsRow{
+				terms: []linearTerm{{index: p.i, coefficient: -1}, {index: w, coefficient: 1}},
+				lower: math.Inf(-1),
+				upper: 0,
+			},
+			highsRow{
+				terms: []linearTerm{{index: p.j, coefficient: -1}, {index: w, coefficient: 1}},
+				lower: math.Inf(-1),
+				upper: 0,
+			},
+			highsRow{
+				terms: []linearTerm{
+					{index: p.i, coefficient: -1},
+					{index: p.j, coefficient: -1},
+					{index: w, coefficient: 1},
+				},
+				lower: -1,
+				upper: math.Inf(1),
+			},
+		)
+	}
+
+	return rows
+}
+
+// isBinary reports whether a variable only takes the values 0 and 1.
+func isBinary(v mip.Var, semi map[int]semiKind) bool {
+	if _, ok := semi[v.Index()]; ok {
+		return false
+	}
+
+	return (v.IsBool() || v.IsInt()) && v.LowerBound() >= 0 && v.UpperBound() <= 1
+}
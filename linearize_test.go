@@ -0,0 +1,85 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestBinaryProducts(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxOneOf  bool
+		want      float64
+		wantValue [3]float64
+	}{
+		{
+			name:      "unconstrained",
+			want:      -3,
+			wantValue: [3]float64{1, 1, 1},
+		},
+		{
+			name:      "x + y <= 1",
+			maxOneOf:  true,
+			want:      -1,
+			wantValue: [3]float64{0, 1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// minimize x^2 + y^2 - 3xy + z - 3yz, which is not convex
+			model := mip.NewModel()
+			x := model.NewBool()
+			y := model.NewBool()
+			z := model.NewInt(0, 1)
+			objective := model.Objective()
+			objective.NewQuadraticTerm(1, x, x)
+			objective.NewQuadraticTerm(1, y, y)
+			objective.NewQuadraticTerm(-2, x, y)
+			objective.NewQuadraticTerm(-1, y, x)
+			objective.NewTerm(1, z)
+			objective.NewQuadraticTerm(-3, z, y)
+			if test.maxOneOf {
+				c := model.NewConstraint(mip.LessThanOrEqual, 1)
+				c.NewTerm(1, x)
+				c.NewTerm(1, y)
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.ObjectiveValue()-test.want) > 1e-6 {
+				t.Errorf("got objective %v, want %v", solution.ObjectiveValue(), test.want)
+			}
+
+			for i, v := range []mip.Var{x, y, z} {
+				if math.Abs(solution.Value(v)-test.wantValue[i]) > 1e-6 {
+					t.Errorf("got value %v of var %d, want %v", solution.Value(v), i, test.wantValue[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryProductsWithInteger(t *testing.T) {
+	// x*y with y in [0, 2] is not a product of binaries.
+	model := mip.NewModel()
+	x := model.NewBool()
+	y := model.NewInt(0, 2)
+	model.Objective().NewQuadraticTerm(1, x, y)
+
+	if _, err := highs.NewSolver(model).Solve(defaultOptions()); err == nil {
+		t.Error("want error, got nil")
+	}
+}
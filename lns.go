@@ -0,0 +1,319 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// Neighborhood returns the integer variables to free in an iteration of a
+// large neighborhood search, given the incumbent and the integer variables
+// of the model. The other integer variables are fixed to their values in
+// the incumbent. Random choices must be drawn from random, so a search is
+// reproducible for a seed.
+type Neighborhood func(incumbent Solution, integers mip.Vars, random *rand.Rand) mip.Vars
+
+// RandomNeighborhood returns a neighborhood that frees a fraction of the
+// integer variables chosen uniformly at random, at least one.
+func RandomNeighborhood(fraction float64) Neighborhood {
+	return func(_ Solution, integers mip.Vars, random *rand.Rand) mip.Vars {
+		size := int(math.Ceil(fraction * float64(len(integers))))
+		size = max(1, min(size, len(integers)))
+		free := make(mip.Vars, size)
+		for k, index := range random.Perm(len(integers))[:size] {
+			free[k] = integers[index]
+		}
+
+		return free
+	}
+}
+
+// LNSResult is the result of an [LNSSolver].
+type LNSResult struct {
+	// Solution is the incumbent. It is optimal if a neighborhood that
+	// frees all integer variables was solved to optimality, suboptimal if
+	// it has values otherwise.
+	Solution Solution
+	// Iterations is the number of neighborhoods solved.
+	Iterations int
+	// Improvements is the number of neighborhoods that improved the
+	// incumbent.
+	Improvements int
+}
+
+// LNSSolver improves solutions of MIPs that HiGHS cannot solve to
+// optimality in time by large neighborhood search. Starting from an
+// incumbent, every iteration picks one of the neighborhoods at random,
+// fixes the integer variables it does not free to their values in the
+// incumbent and solves the remaining MIP under a short time limit. A
+// solution that improves the objective value becomes the incumbent.
+type LNSSolver struct {
+	model             mip.Model
+	options           Options
+	neighborhoods     []Neighborhood
+	seed              int64
+	iterationDuration time.Duration
+	start             mip.Solution
+}
+
+// NewLNSSolver creates a large neighborhood search for the model with the
+// typed options applied to every solve. By default it frees 20% of the
+// integer variables at random, limits every iteration to a second and uses
+// a seed of 0.
+func NewLNSSolver(model mip.Model, options Options) *LNSSolver {
+	return &LNSSolver{
+		model:             model,
+		options:           options,
+		iterationDuration: time.Second,
+	}
+}
+
+// AddNeighborhood adds a neighborhood. Once a neighborhood is added, the
+// default random neighborhood is no longer used.
+func (s *LNSSolver) AddNeighborhood(neighborhood Neighborhood) {
+	s.neighborhoods = append(s.neighborhoods, neighborhood)
+}
+
+// SetSeed sets the seed of the random choices of neighborhoods and of the
+// variables they free.
+func (s *LNSSolver) SetSeed(seed int64) {
+	s.seed = seed
+}
+
+// SetIterationDuration sets the time limit of the solve of every
+// neighborhood.
+func (s *LNSSolver) SetIterationDuration(duration time.Duration) {
+	s.iterationDuration = duration
+}
+
+// SetStart sets the solution the search starts from. Without one, the
+// search starts from the first solution HiGHS finds for the model.
+func (s *LNSSolver) SetStart(solution mip.Solution) {
+	s.start = solution
+}
+
+// Solve searches until the duration of the options is used up or the
+// incumbent is proven optimal. It returns a solution without values if no
+// incumbent was found, and the solution of HiGHS as soon as HiGHS proves
+// there is none, for example because the model is infeasible.
+func (s *LNSSolver) Solve(options mip.SolveOptions) (LNSResult, error) {
+	start := time.Now()
+	result := LNSResult{Solution: &highsSolution{solutionStatus: timeLimit}}
+	if s.iterationDuration <= 0 {
+		return result, errors.New("highs: LNS needs a positive iteration duration")
+	}
+
+	search, err := newInstance(s.model, s.options, options, start, false)
+	if violated := violatedSolution(err); violated != nil {
+		result.Solution = violated
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	defer search.close()
+
+	input := search.input
+	integers := make(mip.Vars, 0)
+	for _, v := range s.model.Vars() {
+		if input.columnIntegrality[v.Index()] == C.kHighsVarTypeInteger {
+			integers = append(integers, v)
+		}
+	}
+	if len(integers) == 0 {
+		return result, errors.New("highs: LNS needs a model with integer variables")
+	}
+
+	if err := search.pass(); err != nil {
+		return result, err
+	}
+
+	lower := make([]float64, input.numColumns)
+	upper := make([]float64, input.numColumns)
+	for j := range lower {
+		lower[j] = float64(input.columnLowerBound[j])
+		upper[j] = float64(input.columnUpperBound[j])
+	}
+
+	neighborhoods := s.neighborhoods
+	if len(neighborhoods) == 0 {
+		neighborhoods = []Neighborhood{RandomNeighborhood(0.2)}
+	}
+	random := rand.New(rand.NewSource(s.seed))
+	deadline := start.Add(options.Duration)
+	sign := 1.0
+	if s.model.Objective().IsMaximize() {
+		sign = -1.0
+	}
+
+	incumbent, found, err := s.initial(search, deadline)
+	if err != nil || incumbent == nil {
+		return result, err
+	}
+	if !found {
+		incumbent.trim(input)
+		incumbent.runtime = time.Since(start)
+		result.Solution = incumbent
+		return result, nil
+	}
+	result.Solution = incumbent.incumbent(input, start)
+	if incumbent.IsOptimal() {
+		return result, nil
+	}
+
+	indices := make([]int, len(integers))
+	for k, v := range integers {
+		indices[k] = v.Index()
+	}
+	fixedLower := make([]float64, len(integers))
+	fixedUpper := make([]float64, len(integers))
+	for time.Now().Before(deadline) {
+		free := neighborhoods[random.Intn(len(neighborhoods))](result.Solution, integers, random)
+		freed := make(map[int]bool, len(free))
+		for _, v := range free {
+			freed[v.Index()] = true
+		}
+		// freedIntegers counts the integer variables freed, as the
+		// neighborhood may return duplicates or other variables.
+		freedIntegers := 0
+		for k, index := range indices {
+			if freed[index] {
+				fixedLower[k], fixedUpper[k] = lower[index], upper[index]
+				freedIntegers++
+				continue
+			}
+			value := math.Round(incumbent.values[index])
+			fixedLower[k], fixedUpper[k] = value, value
+		}
+		if err := search.changeColumnBounds(indices, fixedLower, fixedUpper); err != nil {
+			return result, err
+		}
+		if err := search.setSolution(incumbent.values); err != nil {
+			return result, err
+		}
+
+		solution, found, err := s.run(search, deadline, s.iterationDuration)
+		if err != nil {
+			return result, err
+		}
+		if !found {
+			continue
+		}
+		result.Iterations++
+
+		improvement := 1e-9 * math.Max(1, math.Abs(incumbent.objectiveValue))
+		if sign*solution.objectiveValue < sign*incumbent.objectiveValue-improvement {
+			incumbent = solution
+			result.Improvements++
+			result.Solution = incumbent.incumbent(input, start)
+		}
+		if solution.IsOptimal() && freedIntegers == len(integers) {
+			incumbent.solutionStatus = optimal
+			result.Solution = incumbent.incumbent(input, start)
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// initial returns the solution the search starts from and true, nil if
+// there is none. Without a start, the model is solved with doubling time
+// limits until HiGHS finds a solution. The time limits are only doubled if
+// HiGHS stopped at one, otherwise the solution of HiGHS, such as an
+// infeasible or unbounded one, is returned with false.
+func (s *LNSSolver) initial(
+	search *instance,
+	deadline time.Time,
+) (*highsSolution, bool, error) {
+	if s.start != nil {
+		if !s.start.HasValues() {
+			return nil, false, errors.New("highs: LNS start has no values")
+		}
+		solution := &highsSolution{
+			values:         make([]float64, search.input.numColumns),
+			objectiveValue: s.start.ObjectiveValue(),
+			solutionStatus: timeLimit,
+		}
+		for _, v := range s.model.Vars() {
+			solution.values[v.Index()] = s.start.Value(v)
+		}
+		return solution, true, nil
+	}
+
+	duration := s.iterationDuration
+	for time.Now().Before(deadline) {
+		solution, found, err := s.run(search, deadline, duration)
+		if err != nil || found {
+			return solution, found, err
+		}
+		if solution != nil && solution.solutionStatus != timeLimit {
+			return solution, false, nil
+		}
+		duration *= 2
+	}
+
+	return nil, false, nil
+}
+
+// run solves the model on the instance for at most the duration, and no
+// longer than until the deadline, and returns its solution and whether
+// HiGHS found a feasible one. The solution is nil if there is no time left.
+func (s *LNSSolver) run(
+	search *instance,
+	deadline time.Time,
+	duration time.Duration,
+) (*highsSolution, bool, error) {
+	limit := time.Now().Add(duration)
+	if limit.After(deadline) {
+		limit = deadline
+	}
+	hasTime, err := search.setDeadline(limit)
+	if err != nil || !hasTime {
+		return nil, false, err
+	}
+
+	solution, err := search.run()
+	if err != nil {
+		return nil, false, err
+	}
+	if solution.IsOptimal() {
+		return solution, true, nil
+	}
+
+	feasible, err := search.hasFeasibleSolution()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return solution, feasible, nil
+}
+
+// incumbent returns a copy of the values and objective value of the
+// solution restricted to the model, reported as suboptimal unless it is
+// optimal.
+func (l *highsSolution) incumbent(input *highsInput, start time.Time) *highsSolution {
+	solution := &highsSolution{
+		values:           append([]float64(nil), l.values[:input.numModelColumns]...),
+		optionDeviations: l.optionDeviations,
+		objectiveValue:   l.objectiveValue,
+		solutionStatus:   l.solutionStatus,
+		runtime:          time.Since(start),
+	}
+	if !solution.IsOptimal() {
+		solution.solutionStatus = timeLimit
+		solution.suboptimal = true
+	}
+
+	return solution
+}
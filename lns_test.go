@@ -0,0 +1,221 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+var (
+	lnsValues   = []float64{12, 9, 7, 14, 5, 8, 11, 6, 10, 4}
+	lnsWeights  = []float64{6, 5, 3, 7, 2, 4, 6, 3, 5, 2}
+	lnsCapacity = 20.0
+)
+
+// knapsack returns the knapsack model over the items.
+func knapsack() (mip.Model, mip.Vars) {
+	model := mip.NewModel()
+	model.Objective().SetMaximize()
+	capacity := model.NewConstraint(mip.LessThanOrEqual, lnsCapacity)
+	x := make(mip.Vars, len(lnsValues))
+	for i := range x {
+		x[i] = model.NewBool()
+		model.Objective().NewTerm(lnsValues[i], x[i])
+		capacity.NewTerm(lnsWeights[i], x[i])
+	}
+
+	return model, x
+}
+
+// bestKnapsack returns the optimal value of the knapsack by enumeration.
+func bestKnapsack() float64 {
+	best := 0.0
+	for set := 0; set < 1<<len(lnsValues); set++ {
+		value, weight := 0.0, 0.0
+		for i := range lnsValues {
+			if set&(1<<i) != 0 {
+				value += lnsValues[i]
+				weight += lnsWeights[i]
+			}
+		}
+		if weight <= lnsCapacity {
+			best = math.Max(best, value)
+		}
+	}
+
+	return best
+}
+
+// empty is the solution packing no item.
+type empty struct{ mip.Solution }
+
+func (empty) HasValues() bool         { return true }
+func (empty) ObjectiveValue() float64 { return 0 }
+func (empty) Value(mip.Var) float64   { return 0 }
+
+func lnsOptions() mip.SolveOptions {
+	options := defaultOptions()
+	options.Duration = time.Second
+
+	return options
+}
+
+func TestLNS(t *testing.T) {
+	want := bestKnapsack()
+	model, x := knapsack()
+
+	solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+	solver.SetStart(empty{})
+	solver.SetIterationDuration(100 * time.Millisecond)
+	solver.AddNeighborhood(highs.RandomNeighborhood(0.3))
+	result, err := solver.Solve(lnsOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solution := result.Solution
+	if !solution.HasValues() || result.Iterations == 0 {
+		t.Fatal("expected an incumbent after iterations")
+	}
+	if !solution.IsSubOptimal() || solution.IsOptimal() {
+		t.Error("expected a suboptimal incumbent")
+	}
+
+	value, weight := 0.0, 0.0
+	for i, v := range x {
+		value += lnsValues[i] * solution.Value(v)
+		weight += lnsWeights[i] * solution.Value(v)
+	}
+	if math.Abs(value-solution.ObjectiveValue()) > 1e-6 || weight > lnsCapacity+1e-6 {
+		t.Errorf("got value %v and weight %v, want objective value %v within capacity",
+			value, weight, solution.ObjectiveValue())
+	}
+	if value > want+1e-6 {
+		t.Errorf("got value %v, above the optimum %v", value, want)
+	}
+}
+
+func TestLNSOptimal(t *testing.T) {
+	want := bestKnapsack()
+	model, _ := knapsack()
+
+	// Freeing all variables solves the model.
+	all := func(_ highs.Solution, integers mip.Vars, _ *rand.Rand) mip.Vars {
+		return integers
+	}
+	solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+	solver.SetStart(empty{})
+	solver.AddNeighborhood(all)
+	result, err := solver.Solve(lnsOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Solution.IsOptimal() || result.Iterations != 1 {
+		t.Fatalf("got optimal %v after %d iterations, want optimal after one",
+			result.Solution.IsOptimal(), result.Iterations)
+	}
+	if math.Abs(result.Solution.ObjectiveValue()-want) > 1e-6 {
+		t.Errorf("got objective %v, want %v", result.Solution.ObjectiveValue(), want)
+	}
+}
+
+func TestLNSOptimalNeedsAllIntegers(t *testing.T) {
+	model, _ := knapsack()
+	continuous := model.NewFloat(0, 1)
+
+	// Duplicates and continuous variables do not make up for the first
+	// integer variable, which stays fixed.
+	allButFirst := func(_ highs.Solution, integers mip.Vars, _ *rand.Rand) mip.Vars {
+		return append(append(mip.Vars{continuous}, integers[1:]...), integers[1])
+	}
+	solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+	solver.SetStart(empty{})
+	solver.AddNeighborhood(allButFirst)
+	result, err := solver.Solve(lnsOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Solution.IsOptimal() {
+		t.Error("got optimal solution with a fixed integer variable")
+	}
+}
+
+func TestLNSInfeasible(t *testing.T) {
+	// x + y = 3 has no solution with x and y even.
+	model := mip.NewModel()
+	x := model.NewInt(0, 10)
+	y := model.NewInt(0, 10)
+	model.Objective().NewTerm(1, x)
+	sum := model.NewConstraint(mip.Equal, 3)
+	sum.NewTerm(1, x)
+	sum.NewTerm(1, y)
+	for _, v := range []mip.Var{x, y} {
+		half := model.NewInt(0, 5)
+		even := model.NewConstraint(mip.Equal, 0)
+		even.NewTerm(1, v)
+		even.NewTerm(-2, half)
+	}
+
+	solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+	solver.SetIterationDuration(10 * time.Millisecond)
+	options := defaultOptions()
+	result, err := solver.Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Solution.IsInfeasible() {
+		t.Error("expected infeasible solution")
+	}
+	if result.Solution.RunTime() >= options.Duration {
+		t.Errorf("got runtime %v, want less than the duration %v",
+			result.Solution.RunTime(), options.Duration)
+	}
+}
+
+func TestLNSSeed(t *testing.T) {
+	neighborhoods := func(seed int64) [][]int {
+		model, _ := knapsack()
+		freed := make([][]int, 0)
+		random := highs.RandomNeighborhood(0.3)
+		record := func(incumbent highs.Solution, integers mip.Vars, r *rand.Rand) mip.Vars {
+			free := random(incumbent, integers, r)
+			indices := make([]int, len(free))
+			for k, v := range free {
+				indices[k] = v.Index()
+			}
+			freed = append(freed, indices)
+			return free
+		}
+
+		solver := highs.NewLNSSolver(model, highs.DefaultOptions())
+		solver.SetStart(empty{})
+		solver.SetSeed(seed)
+		solver.SetIterationDuration(50 * time.Millisecond)
+		solver.AddNeighborhood(record)
+		options := lnsOptions()
+		options.Duration = 300 * time.Millisecond
+		if _, err := solver.Solve(options); err != nil {
+			t.Fatal(err)
+		}
+		return freed
+	}
+
+	first, second := neighborhoods(7), neighborhoods(7)
+	n := min(len(first), len(second))
+	if n == 0 {
+		t.Fatal("expected neighborhoods")
+	}
+	if !reflect.DeepEqual(first[:n], second[:n]) {
+		t.Errorf("got neighborhoods %v and %v, want the same for a seed", first[:n], second[:n])
+	}
+}
@@ -0,0 +1,77 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"github.com/nextmv-io/go-mip"
+)
+
+// Model is a [mip.Model] with modeling features specific to HiGHS. It must
+// only be solved with the solvers of this package, other solvers do not know
+// about the features and would solve a different model.
+type Model struct {
+	mip.Model
+	rangedConstraints []*RangedConstraint
+	semiVars          map[int]semiKind
+	naming            Naming
+	objectiveConstant float64
+}
+
+// NewModel creates an empty model.
+func NewModel() *Model {
+	return &Model{
+		Model: mip.NewModel(),
+	}
+}
+
+// Copy returns a copy of the model, including its quadratic objective terms
+// and the features specific to HiGHS.
+func (m *Model) Copy() mip.Model {
+	model := &Model{
+		Model:             m.Model.Copy(),
+		rangedConstraints: make([]*RangedConstraint, len(m.rangedConstraints)),
+		naming:            m.naming,
+		objectiveConstant: m.objectiveConstant,
+	}
+	vars := model.Vars()
+	// The copy of go-mip drops the quadratic terms of the objective.
+	for _, t := range m.Objective().QuadraticTerms() {
+		model.Objective().NewQuadraticTerm(
+			t.Coefficient(),
+			vars[t.Var1().Index()],
+			vars[t.Var2().Index()],
+		)
+	}
+	for i, c := range m.rangedConstraints {
+		model.rangedConstraints[i] = c.copyTo(vars)
+	}
+	for index, kind := range m.semiVars {
+		model.setSemiKind(vars[index], kind)
+	}
+
+	return model
+}
+
+// AddObjectiveConstant adds a constant to the objective. Invoking it multiple
+// times sums the constants, for example of variables fixed and substituted
+// out of the model.
+func (m *Model) AddObjectiveConstant(constant float64) {
+	m.objectiveConstant += constant
+}
+
+// ObjectiveConstant returns the constant of the objective. It is passed to
+// HiGHS as the objective offset, so the objective value of a solution
+// includes it.
+func (m *Model) ObjectiveConstant() float64 {
+	return m.objectiveConstant
+}
+
+// objectiveConstant returns the objective constant of models implementing
+// ObjectiveConstant() float64, such as *Model, and 0 for others.
+func objectiveConstant(model mip.Model) float64 {
+	if m, ok := model.(interface{ ObjectiveConstant() float64 }); ok {
+		return m.ObjectiveConstant()
+	}
+
+	return 0
+}
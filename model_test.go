@@ -0,0 +1,118 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestObjectiveConstant(t *testing.T) {
+	tests := []struct {
+		name  string
+		model func() *highs.Model
+		want  float64
+	}{
+		{
+			name: "linear",
+			model: func() *highs.Model {
+				// minimize x + 10 with x >= 2
+				model := highs.NewModel()
+				x := model.NewFloat(2, 5)
+				model.Objective().NewTerm(1, x)
+				model.AddObjectiveConstant(4)
+				model.AddObjectiveConstant(6)
+				return model
+			},
+			want: 12,
+		},
+		{
+			name: "maximize",
+			model: func() *highs.Model {
+				// maximize x - 10 with x <= 5
+				model := highs.NewModel()
+				x := model.NewInt(2, 5)
+				model.Objective().SetMaximize()
+				model.Objective().NewTerm(1, x)
+				model.AddObjectiveConstant(-10)
+				return model
+			},
+			want: -5,
+		},
+		{
+			name: "quadratic",
+			model: func() *highs.Model {
+				// minimize (x - 1)^2 = x^2 - 2x + 1
+				model := highs.NewModel()
+				x := model.NewFloat(-5, 5)
+				model.Objective().NewTerm(-2, x)
+				model.Objective().NewQuadraticTerm(1, x, x)
+				model.AddObjectiveConstant(1)
+				return model
+			},
+			want: 0,
+		},
+		{
+			name: "empty",
+			model: func() *highs.Model {
+				model := highs.NewModel()
+				model.AddObjectiveConstant(3)
+				return model
+			},
+			want: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := test.model()
+			for _, m := range []mip.Model{model, model.Copy()} {
+				solution, err := highs.NewSolver(m).Solve(defaultOptions())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !solution.IsOptimal() {
+					t.Fatal("expected optimal solution")
+				}
+
+				if math.Abs(solution.ObjectiveValue()-test.want) > 1e-6 {
+					t.Errorf("got %v, want %v", solution.ObjectiveValue(), test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestModelCopyQuadraticTerms(t *testing.T) {
+	model := highs.NewModel()
+	x := model.NewFloat(-5, 5)
+	y := model.NewFloat(-5, 5)
+	model.Objective().NewQuadraticTerm(1, x, x)
+	model.Objective().NewQuadraticTerm(0.5, x, y)
+	model.Objective().NewQuadraticTerm(2, y, y)
+
+	copied := model.Copy()
+	want := map[[2]int]float64{{0, 0}: 1, {0, 1}: 0.5, {1, 1}: 2}
+	got := map[[2]int]float64{}
+	for _, term := range copied.Objective().QuadraticTerms() {
+		key := [2]int{term.Var1().Index(), term.Var2().Index()}
+		if key[0] > key[1] {
+			key[0], key[1] = key[1], key[0]
+		}
+		got[key] += term.Coefficient()
+	}
+
+	for key, coefficient := range want {
+		if got[key] != coefficient {
+			t.Errorf("got coefficient %v for %v, want %v", got[key], key, coefficient)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("got quadratic terms %v, want %v", got, want)
+	}
+}
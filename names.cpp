@@ -0,0 +1,72 @@
+// © 2019-present nextmv.io inc
+
+#include "names.h"
+
+#include <utility>
+
+#include "Highs.h"
+
+HighsInt Highs_passModelWithNames(
+    void* highs, const HighsInt num_col, const HighsInt num_row,
+    const HighsInt num_nz, const HighsInt q_num_nz, const HighsInt sense,
+    const double offset, const double* col_cost, const double* col_lower,
+    const double* col_upper, const double* row_lower, const double* row_upper,
+    const HighsInt* a_start, const HighsInt* a_index, const double* a_value,
+    const HighsInt* q_start, const HighsInt* q_index, const double* q_value,
+    const HighsInt* integrality, const char* const* col_names,
+    const char* const* row_names) {
+  HighsModel model;
+  HighsLp& lp = model.lp_;
+  lp.num_col_ = num_col;
+  lp.num_row_ = num_row;
+  lp.sense_ = sense == static_cast<HighsInt>(ObjSense::kMaximize)
+                  ? ObjSense::kMaximize
+                  : ObjSense::kMinimize;
+  lp.offset_ = offset;
+  if (num_col > 0) {
+    lp.col_cost_.assign(col_cost, col_cost + num_col);
+    lp.col_lower_.assign(col_lower, col_lower + num_col);
+    lp.col_upper_.assign(col_upper, col_upper + num_col);
+  }
+  if (num_row > 0) {
+    lp.row_lower_.assign(row_lower, row_lower + num_row);
+    lp.row_upper_.assign(row_upper, row_upper + num_row);
+  }
+
+  lp.a_matrix_.format_ = MatrixFormat::kRowwise;
+  lp.a_matrix_.num_col_ = num_col;
+  lp.a_matrix_.num_row_ = num_row;
+  lp.a_matrix_.start_.assign(a_start, a_start + num_row);
+  lp.a_matrix_.start_.push_back(num_nz);
+  if (num_nz > 0) {
+    lp.a_matrix_.index_.assign(a_index, a_index + num_nz);
+    lp.a_matrix_.value_.assign(a_value, a_value + num_nz);
+  }
+
+  if (integrality != nullptr) {
+    lp.integrality_.resize(num_col);
+    for (HighsInt i = 0; i < num_col; i++) {
+      lp.integrality_[i] = static_cast<HighsVarType>(integrality[i]);
+    }
+  }
+
+  if (col_names != nullptr) {
+    lp.col_names_.assign(col_names, col_names + num_col);
+  }
+  if (row_names != nullptr) {
+    lp.row_names_.assign(row_names, row_names + num_row);
+  }
+
+  if (q_num_nz > 0) {
+    HighsHessian& hessian = model.hessian_;
+    hessian.dim_ = num_col;
+    hessian.format_ = HessianFormat::kTriangular;
+    hessian.start_.assign(q_start, q_start + num_col);
+    hessian.start_.push_back(q_num_nz);
+    hessian.index_.assign(q_index, q_index + q_num_nz);
+    hessian.value_.assign(q_value, q_value + q_num_nz);
+  }
+
+  Highs* h = static_cast<Highs*>(highs);
+  return static_cast<HighsInt>(h->passModel(std::move(model)));
+}
@@ -0,0 +1,177 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+   #include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// maxNameLength is the longest name the LP format accepts.
+const maxNameLength = 255
+
+// Naming defines the names passed to HiGHS for the columns and rows of a
+// model. The names appear in HiGHS logs and in models written by HiGHS. A
+// nil function, or an empty name returned by a function, falls back to the
+// name set on the variable or constraint, and to x<index>, c<index> and
+// r<index> for variables, constraints and ranged constraints without a name.
+//
+// Names are escaped for the MPS and LP formats: characters other than
+// letters, digits, '_' and '.' are replaced by '_', names starting with a
+// digit or '.' are prefixed by '_' and duplicate names get a numeric suffix.
+type Naming struct {
+	// Var returns the name of a variable.
+	Var func(v mip.Var) string
+	// Constraint returns the name of the constraint at index in
+	// [mip.Model.Constraints].
+	Constraint func(c mip.Constraint, index int) string
+	// RangedConstraint returns the name of the ranged constraint at index
+	// in [Model.RangedConstraints].
+	RangedConstraint func(c *RangedConstraint, index int) string
+}
+
+// SetNaming sets the naming of the columns and rows passed to HiGHS.
+func (m *Model) SetNaming(naming Naming) {
+	m.naming = naming
+}
+
+// modelNaming returns the naming of a *Model and the default naming for
+// other models.
+func modelNaming(model mip.Model) Naming {
+	if m, ok := model.(*Model); ok {
+		return m.naming
+	}
+
+	return Naming{}
+}
+
+func (n Naming) varName(v mip.Var) string {
+	if n.Var != nil {
+		if name := n.Var(v); name != "" {
+			return name
+		}
+	}
+	if name := v.Name(); name != "" {
+		return name
+	}
+
+	return "x" + strconv.Itoa(v.Index())
+}
+
+func (n Naming) constraintName(c mip.Constraint, index int) string {
+	if n.Constraint != nil {
+		if name := n.Constraint(c, index); name != "" {
+			return name
+		}
+	}
+	if name := c.Name(); name != "" {
+		return name
+	}
+
+	return "c" + strconv.Itoa(index)
+}
+
+func (n Naming) rangedConstraintName(c *RangedConstraint, index int) string {
+	if n.RangedConstraint != nil {
+		if name := n.RangedConstraint(c, index); name != "" {
+			return name
+		}
+	}
+	if name := c.Name(); name != "" {
+		return name
+	}
+
+	return "r" + strconv.Itoa(index)
+}
+
+// nameEscaper escapes names and keeps them unique.
+type nameEscaper struct {
+	used map[string]bool
+}
+
+func newNameEscaper() *nameEscaper {
+	return &nameEscaper{used: make(map[string]bool)}
+}
+
+// escape returns the name with the characters MPS and LP do not accept
+// replaced and a suffix if the name was returned before.
+func (e *nameEscaper) escape(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '_', r == '.':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	escaped := sb.String()
+	if escaped == "" || escaped[0] == '.' || (escaped[0] >= '0' && escaped[0] <= '9') {
+		escaped = "_" + escaped
+	}
+	if len(escaped) > maxNameLength {
+		escaped = escaped[:maxNameLength]
+	}
+
+	unique := escaped
+	for k := 1; e.used[unique]; k++ {
+		suffix := "_" + strconv.Itoa(k)
+		unique = escaped
+		if len(unique)+len(suffix) > maxNameLength {
+			unique = unique[:maxNameLength-len(suffix)]
+		}
+		unique += suffix
+	}
+	e.used[unique] = true
+
+	return unique
+}
+
+// writeModel writes the model passed to HiGHS, in MPS or LP format by the
+// extension of the path.
+func writeModel(highsPtr unsafe.Pointer, path string) error {
+	filename := C.CString(path)
+	defer C.free(unsafe.Pointer(filename))
+	status := C.Highs_writeModel(highsPtr, filename)
+	if status == C.kHighsStatusError {
+		return fmt.Errorf("HiGHS failed writing the model to %s", path)
+	}
+
+	return nil
+}
+
+func toCStrings(values []string) []*C.char {
+	cStrings := make([]*C.char, len(values))
+	for i, value := range values {
+		cStrings[i] = C.CString(value)
+	}
+
+	return cStrings
+}
+
+func freeCStrings(cStrings []*C.char) {
+	for _, s := range cStrings {
+		C.free(unsafe.Pointer(s))
+	}
+}
+
+// firstCString returns a pointer to the first of the C strings, nil if there
+// are none.
+func firstCString(cStrings []*C.char) **C.char {
+	if len(cStrings) == 0 {
+		return nil
+	}
+
+	return &cStrings[0]
+}
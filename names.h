@@ -0,0 +1,31 @@
+// © 2019-present nextmv.io inc
+
+#ifndef GO_HIGHS_NAMES_H
+#define GO_HIGHS_NAMES_H
+
+#include "util/HighsInt.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Highs_passModelWithNames passes a model to highs like Highs_passModel, with
+// a row-wise constraint matrix and a triangular Hessian, together with the
+// names of its columns and rows, so that highs receives the model in a
+// single passModel. The HiGHS C API has no counterpart in this version. Null
+// names leave the columns or rows without names.
+HighsInt Highs_passModelWithNames(
+    void* highs, const HighsInt num_col, const HighsInt num_row,
+    const HighsInt num_nz, const HighsInt q_num_nz, const HighsInt sense,
+    const double offset, const double* col_cost, const double* col_lower,
+    const double* col_upper, const double* row_lower, const double* row_upper,
+    const HighsInt* a_start, const HighsInt* a_index, const double* a_value,
+    const HighsInt* q_start, const HighsInt* q_index, const double* q_value,
+    const HighsInt* integrality, const char* const* col_names,
+    const char* const* row_names);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,78 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestNames(t *testing.T) {
+	model := highs.NewModel()
+	x := model.NewFloat(0, 10)
+	x.SetName("x y")
+	y := model.NewFloat(0, 10)
+	y.SetName("dup")
+	z := model.NewFloat(0, 10)
+	z.SetName("dup")
+	w := model.NewFloat(0, 10)
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(1, w)
+
+	c := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	c.SetName("1st[cap]")
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+
+	d := model.NewConstraint(mip.LessThanOrEqual, 5)
+	d.NewTerm(1, z)
+	d.NewTerm(1, w)
+
+	r := model.NewRangedConstraint(1, 5)
+	r.NewTerm(1, w)
+
+	model.SetNaming(highs.Naming{
+		Constraint: func(c mip.Constraint, index int) string {
+			if c.Name() != "" {
+				return ""
+			}
+			return fmt.Sprintf("capacity(%d)", index)
+		},
+	})
+
+	options := highs.DefaultOptions()
+	options.WriteModel = filepath.Join(t.TempDir(), "model.mps")
+
+	solution, err := highs.NewSolverWithOptions(model, options).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	written, err := os.ReadFile(options.WriteModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]bool{}
+	for _, field := range strings.Fields(string(written)) {
+		fields[field] = true
+	}
+
+	for _, name := range []string{
+		"x_y", "dup", "dup_1", "x3", "_1st_cap_", "capacity_1_", "r0",
+	} {
+		if !fields[name] {
+			t.Errorf("name %s not found in the written model:\n%s", name, written)
+		}
+	}
+}
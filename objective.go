@@ -0,0 +1,81 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// LinearObjective is a linear objective over the variables of a model. It
+// is used by the solves with several objectives, which replace the objective
+// of the model by them.
+type LinearObjective struct {
+	name     string
+	maximize bool
+	terms    mip.Terms
+}
+
+// NewLinearObjective creates an objective that is minimized, or maximized
+// if maximize is true.
+func NewLinearObjective(maximize bool) *LinearObjective {
+	return &LinearObjective{
+		maximize: maximize,
+		terms:    make(mip.Terms, 0),
+	}
+}
+
+// NewTerm adds a term to the objective. Invoking it multiple times for the
+// same variable sums the coefficients.
+func (o *LinearObjective) NewTerm(coefficient float64, variable mip.Var) mip.Term {
+	if math.IsNaN(coefficient) {
+		panic("objective term coefficient is NaN")
+	}
+
+	t := term{coefficient: coefficient, variable: variable}
+	o.terms = append(o.terms, t)
+
+	return t
+}
+
+// Terms returns the terms of the objective, with the coefficients of the
+// terms of the same variable summed and zero terms dropped.
+func (o *LinearObjective) Terms() mip.Terms {
+	return uniqueTerms(o.terms)
+}
+
+// IsMaximize returns true if the objective is maximized.
+func (o *LinearObjective) IsMaximize() bool {
+	return o.maximize
+}
+
+// Name returns the name of the objective.
+func (o *LinearObjective) Name() string {
+	return o.name
+}
+
+// SetName sets the name of the objective.
+func (o *LinearObjective) SetName(name string) {
+	o.name = name
+}
+
+// Value returns the value of the objective for the values of a solution.
+func (o *LinearObjective) Value(solution mip.Solution) float64 {
+	value := 0.0
+	for _, t := range o.terms {
+		value += t.Coefficient() * solution.Value(t.Var())
+	}
+
+	return value
+}
+
+// costs returns the coefficients of the objective for all columns.
+func (o *LinearObjective) costs(numColumns int) []float64 {
+	costs := make([]float64, numColumns)
+	for _, t := range linearTerms(o.Terms()) {
+		costs[t.index] = t.coefficient
+	}
+
+	return costs
+}
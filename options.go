@@ -0,0 +1,419 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Options are typed HiGHS options. They complement the untyped
+// [mip.ControlOptions] for the options that are used most often. The JSON
+// names match the HiGHS option names. An option that is not set keeps its
+// HiGHS default, or its value in the options file; every option that is set
+// is passed to HiGHS, even if it equals the HiGHS default. Empty strings and
+// zero numbers are not set, as 0 is either invalid or the HiGHS default of
+// those options. Options for which 0 is a valid value that differs from the
+// default are pointers, nil if not set. The command line has no flags for
+// pointer options; set them in JSON or as control options, e.g.
+// -solve.control.int mip_max_nodes=0.
+type Options struct {
+	// Solver selects the algorithm used for LPs and QPs.
+	Solver string `json:"solver,omitempty" usage:"{choose, simplex, ipm} Solver used for LPs and QPs."`
+	// Presolve controls whether presolve is used.
+	Presolve string `json:"presolve,omitempty" usage:"{choose, on, off} Presolve option."`
+	// Parallel controls whether HiGHS uses parallelism.
+	Parallel string `json:"parallel,omitempty" usage:"{choose, on, off} Parallel option."`
+	// Threads is the number of threads used by HiGHS, 0 means automatic.
+	Threads int `json:"threads,omitempty" usage:"Number of threads used by HiGHS (0: automatic)."`
+	// RandomSeed is the random seed used by HiGHS.
+	RandomSeed int `json:"random_seed,omitempty" usage:"Random seed used by HiGHS."`
+	// SimplexStrategy is the strategy of the simplex solver.
+	SimplexStrategy string `json:"simplex_strategy,omitempty" usage:"{choose, dual, dual_pami, dual_sip, primal} Strategy for the simplex solver."`
+	// SimplexIterationLimit is the iteration limit of the simplex solver.
+	SimplexIterationLimit *int `json:"simplex_iteration_limit,omitempty"`
+	// IPMIterationLimit is the iteration limit of the IPM solver.
+	IPMIterationLimit *int `json:"ipm_iteration_limit,omitempty"`
+	// RunCrossover controls whether crossover is run after IPM.
+	RunCrossover string `json:"run_crossover,omitempty" usage:"{on, off} Run the crossover routine for the IPM solver."`
+	// PrimalFeasibilityTolerance is the primal feasibility tolerance.
+	PrimalFeasibilityTolerance float64 `json:"primal_feasibility_tolerance,omitempty" usage:"Primal feasibility tolerance."`
+	// DualFeasibilityTolerance is the dual feasibility tolerance.
+	DualFeasibilityTolerance float64 `json:"dual_feasibility_tolerance,omitempty" usage:"Dual feasibility tolerance."`
+	// IPMOptimalityTolerance is the optimality tolerance of the IPM solver.
+	IPMOptimalityTolerance float64 `json:"ipm_optimality_tolerance,omitempty" usage:"IPM optimality tolerance."`
+	// ObjectiveBound terminates the solve once the objective is proven to
+	// be worse than this bound.
+	ObjectiveBound *Bound `json:"objective_bound,omitempty"`
+	// ObjectiveTarget terminates the solve once a solution at least as good
+	// as this target is found.
+	ObjectiveTarget *Bound `json:"objective_target,omitempty"`
+	// MIPDetectSymmetry controls whether symmetry is detected in MIPs.
+	MIPDetectSymmetry string `json:"mip_detect_symmetry,omitempty" usage:"{on, off} Whether symmetry should be detected in MIPs."`
+	// MIPMaxNodes is the maximum number of branch-and-bound nodes.
+	MIPMaxNodes *int `json:"mip_max_nodes,omitempty"`
+	// MIPMaxStallNodes is the maximum number of nodes where the estimate is
+	// above the cutoff bound.
+	MIPMaxStallNodes *int `json:"mip_max_stall_nodes,omitempty"`
+	// MIPMaxLeaves is the maximum number of leave nodes.
+	MIPMaxLeaves *int `json:"mip_max_leaves,omitempty"`
+	// MIPMaxImprovingSols is the number of improving solutions after which
+	// the MIP solver stops.
+	MIPMaxImprovingSols int `json:"mip_max_improving_sols,omitempty" usage:"Limit on the number of improving solutions found to stop the MIP solver prematurely."`
+	// MIPFeasibilityTolerance is the feasibility tolerance of the MIP solver.
+	MIPFeasibilityTolerance float64 `json:"mip_feasibility_tolerance,omitempty" usage:"MIP feasibility tolerance."`
+	// MIPHeuristicEffort is the effort spent on MIP heuristics.
+	MIPHeuristicEffort *float64 `json:"mip_heuristic_effort,omitempty"`
+	// File is the path of a HiGHS options file. The output flag, duration
+	// and MIP gaps of the solve options and the typed and control options
+	// take precedence over it.
+	File string `json:"file,omitempty" usage:"Path of a HiGHS options file, layered under the solve options and explicitly set options."`
+	// WriteFile is the path the effective HiGHS options of a solve are
+	// written to.
+	WriteFile string `json:"write_file,omitempty" usage:"Path to write the effective HiGHS options of a solve to."`
+	// WriteModel is the path the model passed to HiGHS is written to, in
+	// MPS or LP format by the extension of the path.
+	WriteModel string `json:"write_model,omitempty" usage:"Path to write the model passed to HiGHS to, in MPS or LP format by extension."`
+	// ConvexityCheckLimit is the largest number of variables in quadratic
+	// objective terms for which the objective is checked for convexity
+	// before solving. Larger models skip the check; 0 uses a limit of 2000
+	// and a negative limit disables the check.
+	ConvexityCheckLimit int `json:"convexity_check_limit,omitempty" usage:"Largest number of quadratic variables to check the objective for convexity (0: 2000, negative: no check)."`
+}
+
+// DefaultOptions returns the typed options set to their HiGHS defaults. As
+// all of them are set, they take precedence over an options file; use the
+// zero value of [Options] to keep the values of the file.
+func DefaultOptions() Options {
+	limit := func() *int {
+		limit := math.MaxInt32
+		return &limit
+	}
+	bound, target := Bound(math.Inf(1)), Bound(math.Inf(-1))
+	heuristicEffort := 0.05
+
+	return Options{
+		Solver:                     "choose",
+		Presolve:                   "choose",
+		Parallel:                   "choose",
+		SimplexStrategy:            "dual",
+		SimplexIterationLimit:      limit(),
+		IPMIterationLimit:          limit(),
+		RunCrossover:               "on",
+		PrimalFeasibilityTolerance: 1e-7,
+		DualFeasibilityTolerance:   1e-7,
+		IPMOptimalityTolerance:     1e-8,
+		ObjectiveBound:             &bound,
+		ObjectiveTarget:            &target,
+		MIPDetectSymmetry:          "on",
+		MIPMaxNodes:                limit(),
+		MIPMaxStallNodes:           limit(),
+		MIPMaxLeaves:               limit(),
+		MIPMaxImprovingSols:        math.MaxInt32,
+		MIPFeasibilityTolerance:    1e-6,
+		MIPHeuristicEffort:         &heuristicEffort,
+		ConvexityCheckLimit:        defaultConvexityCheckLimit,
+	}
+}
+
+// Bound is a floating point option value that may be infinite. Infinite
+// values are encoded as "inf" and "-inf" in JSON.
+type Bound float64
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (bound Bound) MarshalJSON() ([]byte, error) {
+	value := float64(bound)
+	if math.IsInf(value, 0) {
+		return json.Marshal(formatFloat(value))
+	}
+
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (bound *Bound) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bound %q: %w", text, err)
+		}
+		*bound = Bound(value)
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*bound = Bound(value)
+
+	return nil
+}
+
+// optionValue is a single HiGHS option with its name and a value of type
+// bool, int, float64 or string.
+type optionValue struct {
+	name  string
+	value any
+}
+
+// simplexStrategies are the names of the values of the simplex_strategy
+// option.
+var simplexStrategies = []string{"choose", "dual", "dual_pami", "dual_sip", "primal"}
+
+// defaultConvexityCheckLimit is the convexity check limit used when
+// [Options.ConvexityCheckLimit] is 0.
+const defaultConvexityCheckLimit = 2000
+
+// values lists the options that are set, in a fixed order, with the types
+// of their HiGHS options.
+func (options Options) values() ([]optionValue, error) {
+	values := make([]optionValue, 0)
+	errs := make([]error, 0)
+	addString := func(name, value string) {
+		if value != "" {
+			values = append(values, optionValue{name, value})
+		}
+	}
+	addInt := func(name string, value int) {
+		if value != 0 {
+			values = append(values, optionValue{name, value})
+		}
+	}
+	addFloat := func(name string, value float64) {
+		if value != 0 {
+			values = append(values, optionValue{name, value})
+		}
+	}
+	addOptionalInt := func(name string, value *int) {
+		if value != nil {
+			values = append(values, optionValue{name, *value})
+		}
+	}
+	addOptionalFloat := func(name string, value *float64) {
+		if value != nil {
+			values = append(values, optionValue{name, *value})
+		}
+	}
+	addBound := func(name string, value *Bound) {
+		if value != nil {
+			values = append(values, optionValue{name, float64(*value)})
+		}
+	}
+	addSwitch := func(name, value string) {
+		switch value {
+		case "":
+		case "on", "off":
+			values = append(values, optionValue{name, value == "on"})
+		default:
+			errs = append(errs, &OptionError{
+				Name:   name,
+				Reason: fmt.Sprintf("want on or off, got %q", value),
+			})
+		}
+	}
+
+	addString("solver", options.Solver)
+	addString("presolve", options.Presolve)
+	addString("parallel", options.Parallel)
+	addInt("threads", options.Threads)
+	addInt("random_seed", options.RandomSeed)
+	if options.SimplexStrategy != "" {
+		strategy := slices.Index(simplexStrategies, options.SimplexStrategy)
+		if strategy < 0 {
+			errs = append(errs, &OptionError{
+				Name: "simplex_strategy",
+				Reason: fmt.Sprintf(
+					"want one of %s, got %q",
+					strings.Join(simplexStrategies, ", "),
+					options.SimplexStrategy,
+				),
+			})
+		} else {
+			values = append(values, optionValue{"simplex_strategy", strategy})
+		}
+	}
+	addOptionalInt("simplex_iteration_limit", options.SimplexIterationLimit)
+	addOptionalInt("ipm_iteration_limit", options.IPMIterationLimit)
+	addSwitch("run_crossover", options.RunCrossover)
+	addFloat("primal_feasibility_tolerance", options.PrimalFeasibilityTolerance)
+	addFloat("dual_feasibility_tolerance", options.DualFeasibilityTolerance)
+	addFloat("ipm_optimality_tolerance", options.IPMOptimalityTolerance)
+	addBound("objective_bound", options.ObjectiveBound)
+	addBound("objective_target", options.ObjectiveTarget)
+	addSwitch("mip_detect_symmetry", options.MIPDetectSymmetry)
+	addOptionalInt("mip_max_nodes", options.MIPMaxNodes)
+	addOptionalInt("mip_max_stall_nodes", options.MIPMaxStallNodes)
+	addOptionalInt("mip_max_leaves", options.MIPMaxLeaves)
+	addInt("mip_max_improving_sols", options.MIPMaxImprovingSols)
+	addFloat("mip_feasibility_tolerance", options.MIPFeasibilityTolerance)
+	addOptionalFloat("mip_heuristic_effort", options.MIPHeuristicEffort)
+
+	return values, errors.Join(errs...)
+}
+
+// set assigns the value of a HiGHS option to the typed option with the same
+// name. It returns false if there is no such typed option or the value has
+// the wrong type.
+func (options *Options) set(option optionValue) bool {
+	switch value := option.value.(type) {
+	case string:
+		switch option.name {
+		case "solver":
+			options.Solver = value
+		case "presolve":
+			options.Presolve = value
+		case "parallel":
+			options.Parallel = value
+		default:
+			return false
+		}
+	case bool:
+		text := "off"
+		if value {
+			text = "on"
+		}
+		switch option.name {
+		case "run_crossover":
+			options.RunCrossover = text
+		case "mip_detect_symmetry":
+			options.MIPDetectSymmetry = text
+		default:
+			return false
+		}
+	case int:
+		switch option.name {
+		case "threads":
+			options.Threads = value
+		case "random_seed":
+			options.RandomSeed = value
+		case "simplex_strategy":
+			if value < 0 || value >= len(simplexStrategies) {
+				return false
+			}
+			options.SimplexStrategy = simplexStrategies[value]
+		case "simplex_iteration_limit":
+			options.SimplexIterationLimit = &value
+		case "ipm_iteration_limit":
+			options.IPMIterationLimit = &value
+		case "mip_max_nodes":
+			options.MIPMaxNodes = &value
+		case "mip_max_stall_nodes":
+			options.MIPMaxStallNodes = &value
+		case "mip_max_leaves":
+			options.MIPMaxLeaves = &value
+		case "mip_max_improving_sols":
+			options.MIPMaxImprovingSols = value
+		default:
+			return false
+		}
+	case float64:
+		bound := Bound(value)
+		switch option.name {
+		case "primal_feasibility_tolerance":
+			options.PrimalFeasibilityTolerance = value
+		case "dual_feasibility_tolerance":
+			options.DualFeasibilityTolerance = value
+		case "ipm_optimality_tolerance":
+			options.IPMOptimalityTolerance = value
+		case "objective_bound":
+			options.ObjectiveBound = &bound
+		case "objective_target":
+			options.ObjectiveTarget = &bound
+		case "mip_feasibility_tolerance":
+			options.MIPFeasibilityTolerance = value
+		case "mip_heuristic_effort":
+			options.MIPHeuristicEffort = &value
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// primalFeasibilityTolerance returns the primal feasibility tolerance, or
+// its HiGHS default if it is not set.
+func (options Options) primalFeasibilityTolerance() float64 {
+	if options.PrimalFeasibilityTolerance == 0 {
+		return 1e-7
+	}
+
+	return options.PrimalFeasibilityTolerance
+}
+
+// dualFeasibilityTolerance returns the dual feasibility tolerance, or its
+// HiGHS default if it is not set.
+func (options Options) dualFeasibilityTolerance() float64 {
+	if options.DualFeasibilityTolerance == 0 {
+		return 1e-7
+	}
+
+	return options.DualFeasibilityTolerance
+}
+
+// convexityCheckLimit returns the convexity check limit, 0 if the check is
+// disabled.
+func (options Options) convexityCheckLimit() int {
+	switch {
+	case options.ConvexityCheckLimit < 0:
+		return 0
+	case options.ConvexityCheckLimit == 0:
+		return defaultConvexityCheckLimit
+	}
+
+	return options.ConvexityCheckLimit
+}
+
+// setOptions passes the options that are set to HiGHS.
+func setOptions(highsPtr unsafe.Pointer, options Options) error {
+	values, err := options.values()
+	if err != nil {
+		return err
+	}
+
+	for _, option := range values {
+		if err := setOptionValue(highsPtr, option); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setOptionValue(highsPtr unsafe.Pointer, option optionValue) error {
+	switch value := option.value.(type) {
+	case bool:
+		return setBoolOption(highsPtr, option.name, value)
+	case int:
+		return setIntOption(highsPtr, option.name, value)
+	case float64:
+		return setDoubleOption(highsPtr, option.name, value)
+	case string:
+		return setStringOption(highsPtr, option.name, value)
+	}
+
+	return fmt.Errorf("option %s has unsupported type %T", option.name, option.value)
+}
+
+// formatFloat formats a float the way HiGHS does, using "inf" and "-inf" for
+// infinite values.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,814 @@
+// Code generated by build/optionscatalog from lp_data/HighsOptions.h. DO NOT EDIT.
+
+package highs
+
+import "math"
+
+var optionCatalog = []OptionInfo{
+	{
+		Name:        "presolve",
+		Type:        OptionTypeString,
+		Default:     "choose",
+		Advanced:    false,
+		Description: "Presolve option: \"off\", \"choose\" or \"on\"",
+	},
+	{
+		Name:        "solver",
+		Type:        OptionTypeString,
+		Default:     "choose",
+		Advanced:    false,
+		Description: "Solver option: \"simplex\", \"choose\" or \"ipm\". If \"simplex\"/\"ipm\" is chosen then, for a MIP (QP) the integrality constraint (quadratic term) will be ignored",
+	},
+	{
+		Name:        "parallel",
+		Type:        OptionTypeString,
+		Default:     "choose",
+		Advanced:    false,
+		Description: "Parallel option: \"off\", \"choose\" or \"on\"",
+	},
+	{
+		Name:        "time_limit",
+		Type:        OptionTypeFloat,
+		Default:     math.Inf(1),
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Time limit (seconds)",
+	},
+	{
+		Name:        "ranging",
+		Type:        OptionTypeString,
+		Default:     "off",
+		Advanced:    false,
+		Description: "Compute cost, bound, RHS and basic solution ranging: \"off\" or \"on\"",
+	},
+	{
+		Name:        "infinite_cost",
+		Type:        OptionTypeFloat,
+		Default:     1e+20,
+		Min:         1e+15,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Limit on cost coefficient: values larger than this will be treated as infinite",
+	},
+	{
+		Name:        "infinite_bound",
+		Type:        OptionTypeFloat,
+		Default:     1e+20,
+		Min:         1e+15,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Limit on |constraint bound|: values larger than this will be treated as infinite",
+	},
+	{
+		Name:        "small_matrix_value",
+		Type:        OptionTypeFloat,
+		Default:     1e-09,
+		Min:         1e-12,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Lower limit on |matrix entries|: values smaller than this will be treated as zero",
+	},
+	{
+		Name:        "large_matrix_value",
+		Type:        OptionTypeFloat,
+		Default:     1e+15,
+		Min:         1.0,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Upper limit on |matrix entries|: values larger than this will be treated as infinite",
+	},
+	{
+		Name:        "primal_feasibility_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-07,
+		Min:         1e-10,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Primal feasibility tolerance",
+	},
+	{
+		Name:        "dual_feasibility_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-07,
+		Min:         1e-10,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Dual feasibility tolerance",
+	},
+	{
+		Name:        "ipm_optimality_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-08,
+		Min:         1e-12,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "IPM optimality tolerance",
+	},
+	{
+		Name:        "objective_bound",
+		Type:        OptionTypeFloat,
+		Default:     math.Inf(1),
+		Min:         math.Inf(-1),
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Objective bound for termination",
+	},
+	{
+		Name:        "objective_target",
+		Type:        OptionTypeFloat,
+		Default:     math.Inf(-1),
+		Min:         math.Inf(-1),
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "Objective target for termination",
+	},
+	{
+		Name:        "random_seed",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "random seed used in HiGHS",
+	},
+	{
+		Name:        "threads",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "number of threads used by HiGHS (0: automatic)",
+	},
+	{
+		Name:        "highs_debug_level",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         3,
+		Advanced:    false,
+		Description: "Debugging level in HiGHS",
+	},
+	{
+		Name:        "highs_analysis_level",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         63,
+		Advanced:    false,
+		Description: "Analysis level in HiGHS",
+	},
+	{
+		Name:        "simplex_strategy",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         0,
+		Max:         4,
+		Advanced:    false,
+		Description: "Strategy for simplex solver 0 => Choose; 1 => Dual (serial); 2 => Dual (PAMI); 3 => Dual (SIP); 4 => Primal",
+	},
+	{
+		Name:        "simplex_scale_strategy",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         0,
+		Max:         5,
+		Advanced:    false,
+		Description: "Simplex scaling strategy: off / choose / equilibration / forced equilibration / max value 0 / max value 1 (0/1/2/3/4/5)",
+	},
+	{
+		Name:        "simplex_crash_strategy",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         9,
+		Advanced:    false,
+		Description: "Strategy for simplex crash: off / LTSSF / Bixby (0/1/2)",
+	},
+	{
+		Name:        "simplex_dual_edge_weight_strategy",
+		Type:        OptionTypeInt,
+		Default:     -1,
+		Min:         -1,
+		Max:         2,
+		Advanced:    false,
+		Description: "Strategy for simplex dual edge weights: Choose / Dantzig / Devex / Steepest Edge (-1/0/1/2)",
+	},
+	{
+		Name:        "simplex_primal_edge_weight_strategy",
+		Type:        OptionTypeInt,
+		Default:     -1,
+		Min:         -1,
+		Max:         2,
+		Advanced:    false,
+		Description: "Strategy for simplex primal edge weights: Choose / Dantzig / Devex / Steepest Edge (-1/0/1/2)",
+	},
+	{
+		Name:        "simplex_iteration_limit",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "Iteration limit for simplex solver",
+	},
+	{
+		Name:        "simplex_update_limit",
+		Type:        OptionTypeInt,
+		Default:     5000,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "Limit on the number of simplex UPDATE operations",
+	},
+	{
+		Name:        "simplex_min_concurrency",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         1,
+		Max:         8,
+		Advanced:    false,
+		Description: "Minimum level of concurrency in parallel simplex",
+	},
+	{
+		Name:        "simplex_max_concurrency",
+		Type:        OptionTypeInt,
+		Default:     8,
+		Min:         1,
+		Max:         8,
+		Advanced:    false,
+		Description: "Maximum level of concurrency in parallel simplex",
+	},
+	{
+		Name:        "output_flag",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    false,
+		Description: "Enables or disables solver output",
+	},
+	{
+		Name:        "log_to_console",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    false,
+		Description: "Enables or disables console logging",
+	},
+	{
+		Name:        "solution_file",
+		Type:        OptionTypeString,
+		Default:     "",
+		Advanced:    false,
+		Description: "Solution file",
+	},
+	{
+		Name:        "log_file",
+		Type:        OptionTypeString,
+		Default:     "",
+		Advanced:    false,
+		Description: "Log file",
+	},
+	{
+		Name:        "write_solution_to_file",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Write the primal and dual solution to a file",
+	},
+	{
+		Name:        "write_solution_style",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         -1,
+		Max:         3,
+		Advanced:    false,
+		Description: "Style of solution file Raw (computer-readable); Pretty (human-readable): 0 => HiGHS raw; 1 => HiGHS pretty; 2 => Glpsol raw; 3 => Glpsol pretty; ",
+	},
+	{
+		Name:        "glpsol_cost_row_location",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         -2,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "Location of cost row for Glpsol file: -2 => Last; -1 => None; 0 => None if empty, otherwise data file location; 1 <= n <= num_row => Location n; n > num_row => Last",
+	},
+	{
+		Name:        "icrash",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Run iCrash",
+	},
+	{
+		Name:        "icrash_dualize",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Dualise strategy for iCrash",
+	},
+	{
+		Name:        "icrash_strategy",
+		Type:        OptionTypeString,
+		Default:     "ICA",
+		Advanced:    false,
+		Description: "Strategy for iCrash",
+	},
+	{
+		Name:        "icrash_starting_weight",
+		Type:        OptionTypeFloat,
+		Default:     0.001,
+		Min:         1e-10,
+		Max:         1e+50,
+		Advanced:    false,
+		Description: "iCrash starting weight",
+	},
+	{
+		Name:        "icrash_iterations",
+		Type:        OptionTypeInt,
+		Default:     30,
+		Min:         0,
+		Max:         200,
+		Advanced:    false,
+		Description: "iCrash iterations",
+	},
+	{
+		Name:        "icrash_approx_iter",
+		Type:        OptionTypeInt,
+		Default:     50,
+		Min:         0,
+		Max:         100,
+		Advanced:    false,
+		Description: "iCrash approximate minimization iterations",
+	},
+	{
+		Name:        "icrash_exact",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Exact subproblem solution for iCrash",
+	},
+	{
+		Name:        "icrash_breakpoints",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Exact subproblem solution for iCrash",
+	},
+	{
+		Name:        "write_model_file",
+		Type:        OptionTypeString,
+		Default:     "",
+		Advanced:    false,
+		Description: "Write model file",
+	},
+	{
+		Name:        "write_model_to_file",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    false,
+		Description: "Write the model to a file",
+	},
+	{
+		Name:        "mip_detect_symmetry",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    false,
+		Description: "Whether symmetry should be detected",
+	},
+	{
+		Name:        "mip_max_nodes",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "MIP solver max number of nodes",
+	},
+	{
+		Name:        "mip_max_stall_nodes",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "MIP solver max number of nodes where estimate is above cutoff bound",
+	},
+	{
+		Name:        "mip_max_leaves",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "MIP solver max number of leave nodes",
+	},
+	{
+		Name:        "mip_max_improving_sols",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         1,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "limit on the number of improving solutions found to stop the MIP solver prematurely",
+	},
+	{
+		Name:        "mip_lp_age_limit",
+		Type:        OptionTypeInt,
+		Default:     10,
+		Min:         0,
+		Max:         32767,
+		Advanced:    false,
+		Description: "maximal age of dynamic LP rows before they are removed from the LP relaxation",
+	},
+	{
+		Name:        "mip_pool_age_limit",
+		Type:        OptionTypeInt,
+		Default:     30,
+		Min:         0,
+		Max:         1000,
+		Advanced:    false,
+		Description: "maximal age of rows in the cutpool before they are deleted",
+	},
+	{
+		Name:        "mip_pool_soft_limit",
+		Type:        OptionTypeInt,
+		Default:     10000,
+		Min:         1,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "soft limit on the number of rows in the cutpool for dynamic age adjustment",
+	},
+	{
+		Name:        "mip_pscost_minreliable",
+		Type:        OptionTypeInt,
+		Default:     8,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "minimal number of observations before pseudo costs are considered reliable",
+	},
+	{
+		Name:        "mip_min_cliquetable_entries_for_parallelism",
+		Type:        OptionTypeInt,
+		Default:     100000,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "minimal number of entries in the cliquetable before neighborhood queries of the conflict graph use parallel processing",
+	},
+	{
+		Name:        "mip_report_level",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         0,
+		Max:         2,
+		Advanced:    false,
+		Description: "MIP solver reporting level",
+	},
+	{
+		Name:        "mip_feasibility_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-06,
+		Min:         1e-10,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "MIP feasibility tolerance",
+	},
+	{
+		Name:        "mip_heuristic_effort",
+		Type:        OptionTypeFloat,
+		Default:     0.05,
+		Min:         0.0,
+		Max:         1.0,
+		Advanced:    false,
+		Description: "effort spent for MIP heuristics",
+	},
+	{
+		Name:        "mip_rel_gap",
+		Type:        OptionTypeFloat,
+		Default:     0.0001,
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "tolerance on relative gap, |ub-lb|/|ub|, to determine whether optimality has been reached for a MIP instance",
+	},
+	{
+		Name:        "mip_abs_gap",
+		Type:        OptionTypeFloat,
+		Default:     1e-06,
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    false,
+		Description: "tolerance on absolute gap of MIP, |ub-lb|, to determine whether optimality has been reached for a MIP instance",
+	},
+	{
+		Name:        "ipm_iteration_limit",
+		Type:        OptionTypeInt,
+		Default:     2147483647,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    false,
+		Description: "Iteration limit for IPM solver",
+	},
+	{
+		Name:        "run_crossover",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    false,
+		Description: "Run the crossover routine for IPM solver",
+	},
+	{
+		Name:        "log_dev_level",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         3,
+		Advanced:    true,
+		Description: "Output development messages: 0 => none; 1 => info; 2 => verbose",
+	},
+	{
+		Name:        "solve_relaxation",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    true,
+		Description: "Solve the relaxation of discrete model components",
+	},
+	{
+		Name:        "allow_unbounded_or_infeasible",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    true,
+		Description: "Allow ModelStatus::kUnboundedOrInfeasible",
+	},
+	{
+		Name:        "use_implied_bounds_from_presolve",
+		Type:        OptionTypeBool,
+		Default:     false,
+		Advanced:    true,
+		Description: "Use relaxed implied bounds from presolve",
+	},
+	{
+		Name:        "lp_presolve_requires_basis_postsolve",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Prevents LP presolve steps for which postsolve cannot maintain a basis",
+	},
+	{
+		Name:        "mps_parser_type_free",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Use the free format MPS file reader",
+	},
+	{
+		Name:        "keep_n_rows",
+		Type:        OptionTypeInt,
+		Default:     -1,
+		Min:         -1,
+		Max:         1,
+		Advanced:    true,
+		Description: "For multiple N-rows in MPS files: delete rows / delete entries / keep rows (-1/0/1)",
+	},
+	{
+		Name:        "cost_scale_factor",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         -20,
+		Max:         20,
+		Advanced:    true,
+		Description: "Scaling factor for costs",
+	},
+	{
+		Name:        "allowed_matrix_scale_factor",
+		Type:        OptionTypeInt,
+		Default:     20,
+		Min:         0,
+		Max:         30,
+		Advanced:    true,
+		Description: "Largest power-of-two factor permitted when scaling the constraint matrix",
+	},
+	{
+		Name:        "allowed_cost_scale_factor",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         20,
+		Advanced:    true,
+		Description: "Largest power-of-two factor permitted when scaling the costs",
+	},
+	{
+		Name:        "simplex_dualise_strategy",
+		Type:        OptionTypeInt,
+		Default:     -1,
+		Min:         -1,
+		Max:         1,
+		Advanced:    true,
+		Description: "Strategy for dualising before simplex",
+	},
+	{
+		Name:        "simplex_permute_strategy",
+		Type:        OptionTypeInt,
+		Default:     -1,
+		Min:         -1,
+		Max:         1,
+		Advanced:    true,
+		Description: "Strategy for permuting before simplex",
+	},
+	{
+		Name:        "max_dual_simplex_cleanup_level",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    true,
+		Description: "Max level of dual simplex cleanup",
+	},
+	{
+		Name:        "max_dual_simplex_phase1_cleanup_level",
+		Type:        OptionTypeInt,
+		Default:     2,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    true,
+		Description: "Max level of dual simplex phase 1 cleanup",
+	},
+	{
+		Name:        "simplex_price_strategy",
+		Type:        OptionTypeInt,
+		Default:     3,
+		Min:         0,
+		Max:         3,
+		Advanced:    true,
+		Description: "Strategy for PRICE in simplex",
+	},
+	{
+		Name:        "simplex_unscaled_solution_strategy",
+		Type:        OptionTypeInt,
+		Default:     1,
+		Min:         0,
+		Max:         2,
+		Advanced:    true,
+		Description: "Strategy for solving unscaled LP in simplex",
+	},
+	{
+		Name:        "simplex_initial_condition_check",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Perform initial basis condition check in simplex",
+	},
+	{
+		Name:        "no_unnecessary_rebuild_refactor",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "No unnecessary refactorization on simplex rebuild",
+	},
+	{
+		Name:        "simplex_initial_condition_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e+14,
+		Min:         1.0,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Tolerance on initial basis condition in simplex",
+	},
+	{
+		Name:        "rebuild_refactor_solution_error_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-08,
+		Min:         math.Inf(-1),
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Tolerance on solution error when considering refactorization on simplex rebuild",
+	},
+	{
+		Name:        "dual_steepest_edge_weight_error_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     math.Inf(1),
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Tolerance on dual steepest edge weight errors",
+	},
+	{
+		Name:        "dual_steepest_edge_weight_log_error_threshold",
+		Type:        OptionTypeFloat,
+		Default:     10.0,
+		Min:         1.0,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Threshold on dual steepest edge weight errors for Devex switch",
+	},
+	{
+		Name:        "dual_simplex_cost_perturbation_multiplier",
+		Type:        OptionTypeFloat,
+		Default:     1.0,
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Dual simplex cost perturbation multiplier: 0 => no perturbation",
+	},
+	{
+		Name:        "primal_simplex_bound_perturbation_multiplier",
+		Type:        OptionTypeFloat,
+		Default:     1.0,
+		Min:         0.0,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Primal simplex bound perturbation multiplier: 0 => no perturbation",
+	},
+	{
+		Name:        "dual_simplex_pivot_growth_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-09,
+		Min:         1e-12,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Dual simplex pivot growth tolerance",
+	},
+	{
+		Name:        "presolve_pivot_threshold",
+		Type:        OptionTypeFloat,
+		Default:     0.01,
+		Min:         0.0008,
+		Max:         0.5,
+		Advanced:    true,
+		Description: "Matrix factorization pivot threshold for substitutions in presolve",
+	},
+	{
+		Name:        "presolve_rule_off",
+		Type:        OptionTypeInt,
+		Default:     0,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    true,
+		Description: "Bit mask of presolve rules that are not allowed",
+	},
+	{
+		Name:        "presolve_rule_logging",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Log effectiveness of presolve rules for LP",
+	},
+	{
+		Name:        "presolve_substitution_maxfillin",
+		Type:        OptionTypeInt,
+		Default:     10,
+		Min:         0,
+		Max:         2147483647,
+		Advanced:    true,
+		Description: "Maximal fillin allowed for substitutions in presolve",
+	},
+	{
+		Name:        "factor_pivot_threshold",
+		Type:        OptionTypeFloat,
+		Default:     0.1,
+		Min:         0.0008,
+		Max:         0.5,
+		Advanced:    true,
+		Description: "Matrix factorization pivot threshold",
+	},
+	{
+		Name:        "factor_pivot_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-10,
+		Min:         0.0,
+		Max:         1.0,
+		Advanced:    true,
+		Description: "Matrix factorization pivot tolerance",
+	},
+	{
+		Name:        "start_crossover_tolerance",
+		Type:        OptionTypeFloat,
+		Default:     1e-08,
+		Min:         1e-12,
+		Max:         math.Inf(1),
+		Advanced:    true,
+		Description: "Tolerance to be satisfied before IPM crossover will start",
+	},
+	{
+		Name:        "use_original_HFactor_logic",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Use original HFactor logic for sparse vs hyper-sparse TRANs",
+	},
+	{
+		Name:        "less_infeasible_DSE_check",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Check whether LP is candidate for LiDSE",
+	},
+	{
+		Name:        "less_infeasible_DSE_choose_row",
+		Type:        OptionTypeBool,
+		Default:     true,
+		Advanced:    true,
+		Description: "Use LiDSE if LP has right properties",
+	},
+}
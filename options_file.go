@@ -0,0 +1,199 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+/*
+   #include "highs.h"
+   #include <stdlib.h>
+*/
+import "C"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// ReadControlOptions reads a HiGHS options file and returns its options as
+// control options. An options file holds one "name = value" pair per line;
+// lines starting with # are comments. This is the format written by the
+// HiGHS binary and by [Options.WriteFile].
+func ReadControlOptions(path string) (mip.ControlOptions, error) {
+	values, err := readOptionsFile(path)
+	if err != nil {
+		return mip.ControlOptions{}, err
+	}
+
+	return toControlOptions(values)
+}
+
+// ReadOptions reads a HiGHS options file into typed options. The options of
+// the file that have no typed equivalent are returned as control options.
+// Options not present in the file are not set.
+func ReadOptions(path string) (Options, mip.ControlOptions, error) {
+	values, err := readOptionsFile(path)
+	if err != nil {
+		return Options{}, mip.ControlOptions{}, err
+	}
+
+	options := Options{}
+	remaining := make([]optionValue, 0)
+	for _, value := range values {
+		if !options.set(value) {
+			remaining = append(remaining, value)
+		}
+	}
+
+	controlOptions, err := toControlOptions(remaining)
+	if err != nil {
+		return Options{}, mip.ControlOptions{}, err
+	}
+
+	return options, controlOptions, nil
+}
+
+func readOptionsFile(path string) ([]optionValue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading HiGHS options file: %w", err)
+	}
+	defer file.Close()
+
+	values, err := parseOptionsFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading HiGHS options file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// parseOptionsFile parses the options of a HiGHS options file and checks
+// them against the catalog.
+func parseOptionsFile(r io.Reader) ([]optionValue, error) {
+	values := make([]optionValue, 0)
+	errs := make([]error, 0)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(text, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: want \"name = value\", got %q", line, text))
+			continue
+		}
+		name = strings.TrimSpace(name)
+		raw = strings.TrimSpace(raw)
+
+		value, err := parseOptionValue(name, raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		values = append(values, optionValue{name: name, value: value})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// parseOptionValue converts the text of an option value to the type the
+// catalog defines for the option.
+func parseOptionValue(name, raw string) (any, error) {
+	info, ok := LookupOption(name)
+	if !ok {
+		return nil, &OptionError{
+			Name:        name,
+			Reason:      "unknown option",
+			Suggestions: suggestOptions(name),
+		}
+	}
+
+	var value any
+	var err error
+	switch info.Type {
+	case OptionTypeBool:
+		switch strings.ToLower(raw) {
+		case "on":
+			value = true
+		case "off":
+			value = false
+		default:
+			value, err = strconv.ParseBool(raw)
+		}
+	case OptionTypeInt:
+		value, err = strconv.Atoi(raw)
+	case OptionTypeFloat:
+		value, err = strconv.ParseFloat(raw, 64)
+	case OptionTypeString:
+		value = raw
+	}
+
+	if err != nil {
+		return nil, &OptionError{
+			Name:   name,
+			Reason: fmt.Sprintf("value %q is not a valid %s value", raw, info.Type),
+		}
+	}
+
+	return value, validateOption(name, value)
+}
+
+// toControlOptions formats option values as control options.
+func toControlOptions(values []optionValue) (mip.ControlOptions, error) {
+	groups := map[OptionType][]string{}
+	for _, option := range values {
+		var text string
+		var optionType OptionType
+		switch value := option.value.(type) {
+		case bool:
+			optionType, text = OptionTypeBool, strconv.FormatBool(value)
+		case int:
+			optionType, text = OptionTypeInt, strconv.Itoa(value)
+		case float64:
+			optionType, text = OptionTypeFloat, formatFloat(value)
+		case string:
+			optionType, text = OptionTypeString, value
+		}
+		if strings.ContainsAny(text, ",=") {
+			return mip.ControlOptions{}, &OptionError{
+				Name:   option.name,
+				Reason: fmt.Sprintf("value %q cannot be expressed as a control option", text),
+			}
+		}
+		groups[optionType] = append(groups[optionType], option.name+"="+text)
+	}
+
+	return mip.ControlOptions{
+		Bool:   strings.Join(groups[OptionTypeBool], ","),
+		Float:  strings.Join(groups[OptionTypeFloat], ","),
+		Int:    strings.Join(groups[OptionTypeInt], ","),
+		String: strings.Join(groups[OptionTypeString], ","),
+	}, nil
+}
+
+// writeOptionsFile writes the effective options of the HiGHS instance.
+func writeOptionsFile(highsPtr unsafe.Pointer, path string) error {
+	filename := C.CString(path)
+	defer C.free(unsafe.Pointer(filename))
+	status := C.Highs_writeOptions(highsPtr, filename)
+	if status == C.kHighsStatusError {
+		return fmt.Errorf("HiGHS failed writing options to %s", path)
+	}
+
+	return nil
+}
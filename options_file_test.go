@@ -0,0 +1,185 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+)
+
+func writeOptionsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "highs.opt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadControlOptions(t *testing.T) {
+	path := writeOptionsFile(t, `# tuned for customer A
+presolve = off
+threads = 2
+mip_heuristic_effort = 0.2
+mip_detect_symmetry = false
+objective_bound = inf
+`)
+
+	controlOptions, err := highs.ReadControlOptions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if controlOptions.String != "presolve=off" {
+		t.Errorf("got string options %q", controlOptions.String)
+	}
+
+	if controlOptions.Int != "threads=2" {
+		t.Errorf("got int options %q", controlOptions.Int)
+	}
+
+	if controlOptions.Float != "mip_heuristic_effort=0.2,objective_bound=inf" {
+		t.Errorf("got float options %q", controlOptions.Float)
+	}
+
+	if controlOptions.Bool != "mip_detect_symmetry=false" {
+		t.Errorf("got bool options %q", controlOptions.Bool)
+	}
+}
+
+func TestReadOptions(t *testing.T) {
+	path := writeOptionsFile(t, `presolve = off
+mip_max_nodes = 100
+mip_pool_soft_limit = 5000
+`)
+
+	options, controlOptions, err := highs.ReadOptions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := 100
+	want := highs.Options{Presolve: "off", MIPMaxNodes: &nodes}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("got %+v, want %+v", options, want)
+	}
+
+	if controlOptions.Int != "mip_pool_soft_limit=5000" {
+		t.Errorf("got int options %q", controlOptions.Int)
+	}
+}
+
+func TestReadOptionsFileErrors(t *testing.T) {
+	path := writeOptionsFile(t, `presolv = off
+threads = two
+`)
+
+	_, err := highs.ReadControlOptions(path)
+
+	var optionError *highs.OptionError
+	if !errors.As(err, &optionError) {
+		t.Fatalf("want OptionError, got %v", err)
+	}
+
+	if optionError.Name != "presolv" {
+		t.Errorf("got name %v, want presolv", optionError.Name)
+	}
+}
+
+func TestOptionsFileSolve(t *testing.T) {
+	path := writeOptionsFile(t, `presolve = off
+threads = 2
+`)
+	written := filepath.Join(t.TempDir(), "effective.opt")
+
+	options := highs.Options{File: path}
+	options.WriteFile = written
+	// Explicit options take precedence over the options file.
+	options.Threads = 1
+
+	solution, err := highs.NewSolverWithOptions(catalogModel(), options).
+		Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]any{}
+	for _, d := range solution.(highs.Solution).OptionDeviations() {
+		found[d.Name] = d.Value
+	}
+
+	if found["presolve"] != "off" || found["threads"] != 1 {
+		t.Errorf("got deviations %v, want presolve=off and threads=1", found)
+	}
+
+	effective, _, err := highs.ReadOptions(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if effective.Presolve != "off" || effective.Threads != 1 {
+		t.Errorf("got effective options %+v", effective)
+	}
+}
+
+func TestOptionsFileDefaultOverride(t *testing.T) {
+	path := writeOptionsFile(t, `presolve = off
+`)
+
+	options := highs.Options{File: path}
+	// A typed option set to its HiGHS default still overrides the file.
+	options.Presolve = "choose"
+
+	solution, err := highs.NewSolverWithOptions(catalogModel(), options).
+		Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range solution.(highs.Solution).OptionDeviations() {
+		if d.Name == "presolve" {
+			t.Errorf("got presolve = %v, want the default", d.Value)
+		}
+	}
+}
+
+func TestSolveOptionsOverrideOptionsFile(t *testing.T) {
+	// HiGHS writes the time limit and gaps of a solve to its options files.
+	path := writeOptionsFile(t, `time_limit = 1000
+mip_rel_gap = 0.25
+presolve = off
+`)
+
+	options := highs.Options{File: path}
+	solveOptions := defaultOptions()
+
+	solution, err := highs.NewSolverWithOptions(catalogModel(), options).
+		Solve(solveOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]any{}
+	for _, d := range solution.(highs.Solution).OptionDeviations() {
+		found[d.Name] = d.Value
+	}
+
+	limit, ok := found["time_limit"].(float64)
+	if !ok || limit > solveOptions.Duration.Seconds() {
+		t.Errorf("got time_limit = %v, want at most %v",
+			found["time_limit"], solveOptions.Duration.Seconds())
+	}
+	if found["mip_rel_gap"] != solveOptions.MIP.Gap.Relative {
+		t.Errorf("got mip_rel_gap = %v, want %v",
+			found["mip_rel_gap"], solveOptions.MIP.Gap.Relative)
+	}
+	if found["presolve"] != "off" {
+		t.Errorf("got presolve = %v, want off", found["presolve"])
+	}
+}
@@ -0,0 +1,176 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestOptionsJSONDefaults(t *testing.T) {
+	options := highs.DefaultOptions()
+	err := json.Unmarshal([]byte(`{"threads": 2, "presolve": "off"}`), &options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := highs.DefaultOptions()
+	want.Threads = 2
+	want.Presolve = "off"
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("got %+v, want %+v", options, want)
+	}
+}
+
+func TestDefaultOptionsMatchHiGHS(t *testing.T) {
+	solution, err := highs.NewSolverWithOptions(catalogModel(), highs.DefaultOptions()).
+		Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(highs.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var typed map[string]any
+	if err := json.Unmarshal(data, &typed); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range solution.(highs.Solution).OptionDeviations() {
+		if _, ok := typed[d.Name]; ok {
+			t.Errorf("got %s = %v, want the HiGHS default %v", d.Name, d.Value, d.Default)
+		}
+	}
+}
+
+func TestOptionsJSONRoundTrip(t *testing.T) {
+	bound := highs.Bound(12.5)
+	target := highs.Bound(math.Inf(-1))
+	nodes := 0
+	options := highs.Options{
+		ObjectiveBound:  &bound,
+		ObjectiveTarget: &target,
+		RunCrossover:    "off",
+		MIPMaxNodes:     &nodes,
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded highs.Options
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded, options) {
+		t.Errorf("got %+v, want %+v", decoded, options)
+	}
+
+	if !math.IsInf(float64(*decoded.ObjectiveTarget), -1) {
+		t.Errorf("got objective target %v, want -inf", *decoded.ObjectiveTarget)
+	}
+}
+
+func TestOptionsZeroValue(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	model.Objective().NewTerm(1, x)
+
+	solver := highs.NewSolverWithOptions(model, highs.Options{Threads: 2})
+	solution, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Error("expected optimal solution")
+	}
+}
+
+func TestOptionsZeroValues(t *testing.T) {
+	nodes, effort := 0, 0.0
+	options := highs.Options{MIPMaxNodes: &nodes, MIPHeuristicEffort: &effort}
+
+	solution, err := highs.NewSolverWithOptions(catalogModel(), options).
+		Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]any{}
+	for _, d := range solution.(highs.Solution).OptionDeviations() {
+		found[d.Name] = d.Value
+	}
+
+	if found["mip_max_nodes"] != 0 || found["mip_heuristic_effort"] != 0.0 {
+		t.Errorf("got deviations %v, want mip_max_nodes=0 and mip_heuristic_effort=0", found)
+	}
+}
+
+func TestOptionsSolve(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewInt(0, 5)
+	c := model.NewConstraint(mip.LessThanOrEqual, 8)
+	c.NewTerm(1, x)
+	c.NewTerm(1, y)
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(2, y)
+
+	options := highs.DefaultOptions()
+	options.Solver = "simplex"
+	options.Presolve = "off"
+	options.Threads = 1
+	options.RandomSeed = 7
+	options.SimplexStrategy = "primal"
+	options.MIPFeasibilityTolerance = 1e-7
+
+	solver := highs.NewSolverWithOptions(model, options)
+	solution, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if int(solution.ObjectiveValue()) != 13 {
+		t.Errorf("expected to have an objective of 13 got %v",
+			int(solution.ObjectiveValue()))
+	}
+}
+
+func TestOptionsInvalidValue(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	model.Objective().NewTerm(1, x)
+
+	options := highs.DefaultOptions()
+	options.Solver = "simplx"
+
+	solver := highs.NewSolverWithOptions(model, options)
+	if _, err := solver.Solve(defaultOptions()); err == nil {
+		t.Error("Want error, got nil")
+	}
+}
+
+func TestOptionsInvalidSwitch(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	model.Objective().NewTerm(1, x)
+
+	options := highs.DefaultOptions()
+	options.RunCrossover = "yes"
+
+	solver := highs.NewSolverWithOptions(model, options)
+	if _, err := solver.Solve(defaultOptions()); err == nil {
+		t.Error("Want error, got nil")
+	}
+}
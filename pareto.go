@@ -0,0 +1,472 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// augmentation is the weight of the other objectives, normalized by their
+// ranges, in the objective of an epsilon-constraint solve. It breaks ties
+// towards points that are not weakly dominated.
+const augmentation = 1e-4
+
+// ParetoMethod is the method used to explore a Pareto front.
+type ParetoMethod int
+
+const (
+	// WeightedSum minimizes weighted sums of the objectives, with weights
+	// on an even grid over the simplex. It only finds points on the convex
+	// hull of the front.
+	WeightedSum ParetoMethod = iota
+	// EpsilonConstraint optimizes the first objective with the others
+	// bounded by values on an even grid between their best and worst
+	// values on the front. It also finds points in non-convex parts of the
+	// front.
+	EpsilonConstraint
+)
+
+// ParetoPoint is a non-dominated solution of a Pareto front.
+type ParetoPoint struct {
+	// Values are the values of the objectives, in the order of the
+	// objectives of the solver.
+	Values []float64
+	// Solution is the solution of the point. Its objective value is the
+	// value of the combined objective of the solve that found it, use
+	// Values for the values of the objectives.
+	Solution Solution
+}
+
+// ParetoSolver explores the Pareto front of a model under several linear
+// objectives. The objective of the model is ignored.
+type ParetoSolver struct {
+	model       mip.Model
+	options     Options
+	objectives  []*LinearObjective
+	method      ParetoMethod
+	steps       int
+	parallelism int
+}
+
+// NewParetoSolver creates a solver exploring the Pareto front of the model
+// under the objectives, with the typed options applied to every solve. By
+// default it uses [WeightedSum] with 5 steps and runs the CPUs divided by
+// the threads of the options solves in parallel, or one solve at a time if
+// the options leave the number of threads to HiGHS.
+func NewParetoSolver(
+	model mip.Model,
+	options Options,
+	objectives ...*LinearObjective,
+) *ParetoSolver {
+	return &ParetoSolver{
+		model:       model,
+		options:     options,
+		objectives:  append([]*LinearObjective(nil), objectives...),
+		method:      WeightedSum,
+		steps:       5,
+		parallelism: defaultParallelism(options),
+	}
+}
+
+// SetMethod sets the method used to explore the front.
+func (s *ParetoSolver) SetMethod(method ParetoMethod) {
+	s.method = method
+}
+
+// SetSteps sets the number of values of the grid of weights or bounds per
+// objective, including both ends. Larger values give denser fronts at the
+// cost of more solves: the weighted sum solves (steps+n-2 choose n-1) and
+// the epsilon-constraint method steps^(n-1) points for n objectives.
+func (s *ParetoSolver) SetSteps(steps int) {
+	s.steps = steps
+}
+
+// SetParallelism sets the number of solves run in parallel, each with its
+// own HiGHS instance.
+func (s *ParetoSolver) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
+// Solve explores the front and returns its non-dominated points, sorted by
+// the value of the first objective from best to worst. It first solves
+// every objective on its own, breaking ties by the sum of the other
+// objectives, to find the range of every objective on the front. The
+// duration of the options is the budget of all solves together; points,
+// including these anchors, that are not solved to optimality are left out.
+// It returns an error if no anchor is solved to optimality.
+func (s *ParetoSolver) Solve(options mip.SolveOptions) ([]ParetoPoint, error) {
+	start := time.Now()
+	if len(s.objectives) < 2 {
+		return nil, errors.New("highs: a Pareto front needs at least two objectives")
+	}
+	if s.steps < 2 {
+		return nil, fmt.Errorf("highs: Pareto front needs at least 2 steps, got %d", s.steps)
+	}
+
+	// Objectives are handled as minimizations: sign*f.
+	n := len(s.objectives)
+	numColumns := len(s.model.Vars())
+	signed := make([][]float64, n)
+	for k, objective := range s.objectives {
+		signed[k] = objective.costs(numColumns)
+		if objective.IsMaximize() {
+			for i := range signed[k] {
+				signed[k][i] = -signed[k][i]
+			}
+		}
+	}
+
+	anchors := make([]*highsSolution, n)
+	err := forEachParallel(n, s.parallelism, func(k int) error {
+		solution, err := s.solveAnchor(signed, k, options, start)
+		anchors[k] = solution
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ideal := make([]float64, n)
+	nadir := make([]float64, n)
+	for k := range s.objectives {
+		ideal[k], nadir[k] = math.Inf(1), math.Inf(-1)
+	}
+	solved := 0
+	for _, anchor := range anchors {
+		if !anchor.IsOptimal() {
+			continue
+		}
+		solved++
+		for j := range s.objectives {
+			value := dot(signed[j], anchor.values)
+			ideal[j] = math.Min(ideal[j], value)
+			nadir[j] = math.Max(nadir[j], value)
+		}
+	}
+	if solved == 0 {
+		return nil, errors.New(
+			"highs: no objective of the Pareto front could be solved to optimality",
+		)
+	}
+	ranges := make([]float64, n)
+	for k := range ranges {
+		ranges[k] = nadir[k] - ideal[k]
+		if ranges[k] <= 1e-9*math.Max(1, math.Abs(ideal[k])) {
+			ranges[k] = 1
+		}
+	}
+
+	subproblems := s.subproblems(signed, ideal, ranges)
+	solutions := make([]*highsSolution, len(subproblems))
+	err = forEachParallel(len(subproblems), s.parallelism, func(i int) error {
+		solution, err := s.solvePoint(subproblems[i].costs, subproblems[i].rows, options, start)
+		solutions[i] = solution
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]ParetoPoint, 0, len(anchors)+len(solutions))
+	for _, solution := range append(anchors, solutions...) {
+		if !solution.IsOptimal() {
+			continue
+		}
+		point := ParetoPoint{Values: make([]float64, n), Solution: solution}
+		for k, objective := range s.objectives {
+			point.Values[k] = objective.Value(solution)
+		}
+		points = append(points, point)
+	}
+
+	return s.nonDominated(points), nil
+}
+
+// paretoSubproblem is a solve of the front: minimize costs subject to the
+// rows added to the model.
+type paretoSubproblem struct {
+	costs []float64
+	rows  []highsRow
+}
+
+func (s *ParetoSolver) subproblems(
+	signed [][]float64,
+	ideal []float64,
+	ranges []float64,
+) []paretoSubproblem {
+	n := len(signed)
+	numColumns := len(signed[0])
+	subproblems := make([]paretoSubproblem, 0)
+
+	if s.method == EpsilonConstraint {
+		// minimize f_0/r_0 + δ Σ f_k/r_k s.t. f_k <= ideal_k + t_k r_k
+		costs := make([]float64, numColumns)
+		for k := range signed {
+			weight := augmentation / ranges[k]
+			if k == 0 {
+				weight = 1 / ranges[0]
+			}
+			for i, c := range signed[k] {
+				costs[i] += weight * c
+			}
+		}
+		forEachGridPoint(n-1, s.steps, func(t []int) {
+			rows := make([]highsRow, n-1)
+			for k := 1; k < n; k++ {
+				epsilon := ideal[k] + float64(t[k-1])/float64(s.steps-1)*ranges[k]
+				rows[k-1] = highsRow{
+					terms: sparse(signed[k]),
+					lower: math.Inf(-1),
+					upper: epsilon + 1e-9*math.Max(1, math.Abs(epsilon)),
+				}
+			}
+			subproblems = append(subproblems, paretoSubproblem{costs: costs, rows: rows})
+		})
+
+		return subproblems
+	}
+
+	// minimize Σ w_k f_k/r_k with Σ w_k = 1, leaving out the anchors.
+	forEachGridPoint(n, s.steps, func(t []int) {
+		total, zeros := 0, 0
+		for _, v := range t {
+			total += v
+			if v == 0 {
+				zeros++
+			}
+		}
+		if total != s.steps-1 || zeros == n-1 {
+			return
+		}
+		costs := make([]float64, numColumns)
+		for k := range signed {
+			weight := float64(t[k]) / float64(s.steps-1) / ranges[k]
+			for i, c := range signed[k] {
+				costs[i] += weight * c
+			}
+		}
+		subproblems = append(subproblems, paretoSubproblem{costs: costs})
+	})
+
+	return subproblems
+}
+
+// solveAnchor minimizes objective k and then the sum of the others with
+// objective k kept at its optimal value.
+func (s *ParetoSolver) solveAnchor(
+	signed [][]float64,
+	k int,
+	options mip.SolveOptions,
+	start time.Time,
+) (*highsSolution, error) {
+	anchor, err := s.newInstance(options, start)
+	if violated := violatedSolution(err); violated != nil {
+		return violated, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer anchor.close()
+
+	solution, err := anchor.solve(signed[k], start.Add(options.Duration))
+	if err != nil || !solution.IsOptimal() {
+		return solution, err
+	}
+
+	z := solution.objectiveValue
+	row := highsRow{
+		terms: sparse(signed[k]),
+		lower: math.Inf(-1),
+		upper: z + 1e-9*math.Max(1, math.Abs(z)),
+	}
+	if _, err := anchor.addRows([]highsRow{row}); err != nil {
+		return nil, err
+	}
+
+	others := make([]float64, len(signed[k]))
+	for j := range signed {
+		if j != k {
+			for i, c := range signed[j] {
+				others[i] += c
+			}
+		}
+	}
+
+	return anchor.solve(others, start.Add(options.Duration))
+}
+
+// solvePoint minimizes the costs subject to the model and the rows.
+func (s *ParetoSolver) solvePoint(
+	costs []float64,
+	rows []highsRow,
+	options mip.SolveOptions,
+	start time.Time,
+) (*highsSolution, error) {
+	point, err := s.newInstance(options, start)
+	if violated := violatedSolution(err); violated != nil {
+		return violated, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer point.close()
+
+	if _, err := point.addRows(rows); err != nil {
+		return nil, err
+	}
+
+	return point.solve(costs, start.Add(options.Duration))
+}
+
+// newInstance creates an instance of the model without its objective.
+func (s *ParetoSolver) newInstance(
+	options mip.SolveOptions,
+	start time.Time,
+) (*instance, error) {
+	i, err := newInstance(s.model, s.options, options, start, false)
+	if err != nil {
+		return nil, err
+	}
+
+	i.dropObjective()
+	if err := i.pass(); err != nil {
+		i.close()
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// nonDominated returns the points no other point dominates, without
+// duplicates, sorted by the first objective from best to worst.
+func (s *ParetoSolver) nonDominated(points []ParetoPoint) []ParetoPoint {
+	// better reports whether a is at least as good as b in every objective,
+	// up to a tolerance.
+	better := func(a, b ParetoPoint) bool {
+		for k, objective := range s.objectives {
+			tolerance := 1e-6 * math.Max(1, math.Abs(b.Values[k]))
+			if objective.IsMaximize() && a.Values[k] < b.Values[k]-tolerance ||
+				!objective.IsMaximize() && a.Values[k] > b.Values[k]+tolerance {
+				return false
+			}
+		}
+		return true
+	}
+
+	front := make([]ParetoPoint, 0, len(points))
+	for i, p := range points {
+		dominated := false
+		for j, q := range points {
+			if i == j || !better(q, p) {
+				continue
+			}
+			// Equal points keep the first of them.
+			if !better(p, q) || j < i {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, p)
+		}
+	}
+
+	sign := 1.0
+	if s.objectives[0].IsMaximize() {
+		sign = -1.0
+	}
+	sort.SliceStable(front, func(i, j int) bool {
+		return sign*front[i].Values[0] < sign*front[j].Values[0]
+	})
+
+	return front
+}
+
+// defaultParallelism returns the number of solves run in parallel by
+// default. HiGHS uses all CPUs if the options leave the number of threads
+// to it, so solves then run one at a time.
+func defaultParallelism(options Options) int {
+	if options.Threads <= 0 {
+		return 1
+	}
+
+	return max(1, runtime.NumCPU()/options.Threads)
+}
+
+// forEachParallel invokes f for 0, ..., n-1 with at most workers
+// invocations running at the same time. It returns the first error.
+func forEachParallel(n, workers int, f func(i int) error) error {
+	workers = max(1, min(workers, n))
+	indices := make(chan int)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachGridPoint invokes f for every point of {0, ..., steps-1}^n.
+func forEachGridPoint(n, steps int, f func(t []int)) {
+	t := make([]int, n)
+	var visit func(k int)
+	visit = func(k int) {
+		if k == n {
+			f(t)
+			return
+		}
+		for v := 0; v < steps; v++ {
+			t[k] = v
+			visit(k + 1)
+		}
+	}
+	visit(0)
+}
+
+// sparse returns the non-zero entries of dense coefficients as terms.
+func sparse(coefficients []float64) []linearTerm {
+	terms := make([]linearTerm, 0)
+	for index, coefficient := range coefficients {
+		if coefficient != 0 {
+			terms = append(terms, linearTerm{index: index, coefficient: coefficient})
+		}
+	}
+
+	return terms
+}
+
+func dot(coefficients []float64, values []float64) float64 {
+	value := 0.0
+	for i, c := range coefficients {
+		value += c * values[i]
+	}
+
+	return value
+}
@@ -0,0 +1,123 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+// paretoItems are the values and weights of items to pick, maximizing the
+// value and minimizing the weight.
+var paretoItems = [][2]float64{{4, 3}, {3, 2}, {2, 2}}
+
+// paretoFront returns the non-dominated (value, weight) pairs by
+// enumeration.
+func paretoFront() map[[2]float64]bool {
+	points := make([][2]float64, 0)
+	for subset := 0; subset < 1<<len(paretoItems); subset++ {
+		point := [2]float64{}
+		for i, item := range paretoItems {
+			if subset&(1<<i) != 0 {
+				point[0] += item[0]
+				point[1] += item[1]
+			}
+		}
+		points = append(points, point)
+	}
+
+	front := make(map[[2]float64]bool)
+	for _, p := range points {
+		dominated := false
+		for _, q := range points {
+			if q != p && q[0] >= p[0] && q[1] <= p[1] {
+				dominated = true
+			}
+		}
+		if !dominated {
+			front[p] = true
+		}
+	}
+
+	return front
+}
+
+func paretoSolver() *highs.ParetoSolver {
+	model := mip.NewModel()
+	value := highs.NewLinearObjective(true)
+	weight := highs.NewLinearObjective(false)
+	for _, item := range paretoItems {
+		x := model.NewBool()
+		value.NewTerm(item[0], x)
+		weight.NewTerm(item[1], x)
+	}
+
+	return highs.NewParetoSolver(model, highs.DefaultOptions(), value, weight)
+}
+
+func TestParetoEpsilonConstraint(t *testing.T) {
+	solver := paretoSolver()
+	solver.SetMethod(highs.EpsilonConstraint)
+	// The weights on the front are the integers 0 to 7.
+	solver.SetSteps(8)
+
+	points, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	front := paretoFront()
+	if len(points) != len(front) {
+		t.Errorf("got %d points, want %d", len(points), len(front))
+	}
+	for i, point := range points {
+		got := [2]float64{math.Round(point.Values[0]), math.Round(point.Values[1])}
+		if !front[got] {
+			t.Errorf("point %v is not on the front", got)
+		}
+		if i > 0 && point.Values[0] >= points[i-1].Values[0] {
+			t.Errorf("points are not sorted by decreasing value: %v after %v",
+				point.Values, points[i-1].Values)
+		}
+	}
+}
+
+func TestParetoWeightedSum(t *testing.T) {
+	solver := paretoSolver()
+	solver.SetParallelism(2)
+
+	points, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	front := paretoFront()
+	extremes := map[[2]float64]bool{}
+	for _, point := range points {
+		got := [2]float64{math.Round(point.Values[0]), math.Round(point.Values[1])}
+		if !front[got] {
+			t.Errorf("point %v is not on the front", got)
+		}
+		extremes[got] = true
+	}
+	for _, want := range [][2]float64{{0, 0}, {9, 7}} {
+		if !extremes[want] {
+			t.Errorf("front is missing %v", want)
+		}
+	}
+}
+
+func TestParetoSingleObjective(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 1)
+	objective := highs.NewLinearObjective(false)
+	objective.NewTerm(1, x)
+
+	solver := highs.NewParetoSolver(model, highs.DefaultOptions(), objective)
+	if _, err := solver.Solve(defaultOptions()); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,356 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// slopeTolerance is the tolerance, relative to the largest slope, below
+// which two slopes of a piecewise-linear function are treated as equal.
+const slopeTolerance = 1e-9
+
+// Breakpoint is a point (X, Y) of a piecewise-linear function.
+type Breakpoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// PiecewiseFormulation is the formulation of a piecewise-linear function
+// that needs binaries.
+type PiecewiseFormulation int
+
+const (
+	// Incremental fills the segments of the function from left to right
+	// with one continuous variable per segment and a binary between
+	// consecutive segments.
+	Incremental PiecewiseFormulation = iota
+	// ConvexCombination writes x and y as a convex combination of two
+	// adjacent breakpoints, with one binary per segment.
+	ConvexCombination
+)
+
+// PiecewiseLinear is the constraint y sense f(x), where f is the
+// piecewise-linear function through the breakpoints. The function is only
+// defined between the first and the last breakpoint, so x is restricted to
+// that interval.
+//
+// No binaries are needed if f is convex and sense is
+// [mip.GreaterThanOrEqual], if f is concave and sense is
+// [mip.LessThanOrEqual], or if f is linear. This is the case for convex
+// costs y that are minimized, or concave revenues that are maximized. Other
+// cases use the [PiecewiseFormulation] set with
+// [PiecewiseLinear.SetFormulation].
+type PiecewiseLinear struct {
+	x           mip.Var
+	y           mip.Var
+	sense       mip.Sense
+	breakpoints []Breakpoint
+	formulation PiecewiseFormulation
+	vars        mip.Vars
+	binaries    mip.Vars
+	added       mip.Constraints
+}
+
+// NewPiecewiseLinear creates the constraint y sense f(x) for the
+// piecewise-linear function f through the breakpoints, ordered by strictly
+// increasing X. Add it to a model with [PiecewiseLinear.AddTo].
+func NewPiecewiseLinear(
+	x mip.Var,
+	y mip.Var,
+	sense mip.Sense,
+	breakpoints []Breakpoint,
+) *PiecewiseLinear {
+	return &PiecewiseLinear{
+		x:           x,
+		y:           y,
+		sense:       sense,
+		breakpoints: append([]Breakpoint(nil), breakpoints...),
+	}
+}
+
+// SetFormulation sets the formulation used when binaries are needed. The
+// default is [Incremental].
+func (p *PiecewiseLinear) SetFormulation(formulation PiecewiseFormulation) {
+	p.formulation = formulation
+}
+
+// Breakpoints returns a copy slice of the breakpoints.
+func (p *PiecewiseLinear) Breakpoints() []Breakpoint {
+	return append([]Breakpoint(nil), p.breakpoints...)
+}
+
+// Evaluate returns f(x). x is clamped to the interval of the breakpoints.
+// It returns the same errors as [PiecewiseLinear.AddTo] for invalid
+// breakpoints.
+func (p *PiecewiseLinear) Evaluate(x float64) (float64, error) {
+	if err := p.validate(); err != nil {
+		return 0, err
+	}
+
+	points := p.breakpoints
+	if x <= points[0].X {
+		return points[0].Y, nil
+	}
+	for k := 1; k < len(points); k++ {
+		if x <= points[k].X {
+			t := (x - points[k-1].X) / (points[k].X - points[k-1].X)
+			return points[k-1].Y + t*(points[k].Y-points[k-1].Y), nil
+		}
+	}
+
+	return points[len(points)-1].Y, nil
+}
+
+// Vars returns the auxiliary variables added to the model, including the
+// binaries.
+func (p *PiecewiseLinear) Vars() mip.Vars {
+	return append(mip.Vars(nil), p.vars...)
+}
+
+// Binaries returns the binaries added to the model. It is empty if the
+// function did not need binaries.
+func (p *PiecewiseLinear) Binaries() mip.Vars {
+	return append(mip.Vars(nil), p.binaries...)
+}
+
+// Constraints returns the constraints added to the model.
+func (p *PiecewiseLinear) Constraints() mip.Constraints {
+	return append(mip.Constraints(nil), p.added...)
+}
+
+// AddTo adds the formulation of the constraint to the model. It returns an
+// error if there are fewer than two breakpoints, the X values are not
+// strictly increasing or a value is not finite.
+func (p *PiecewiseLinear) AddTo(model mip.Model) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	p.vars = make(mip.Vars, 0)
+	p.binaries = make(mip.Vars, 0)
+	p.added = make(mip.Constraints, 0)
+
+	slopes := p.slopes()
+	switch {
+	case isLinear(slopes):
+		p.addSegments(model, slopes[:1], p.sense)
+	case p.sense == mip.GreaterThanOrEqual && isConvex(slopes),
+		p.sense == mip.LessThanOrEqual && isConvex(negate(slopes)):
+		p.addSegments(model, slopes, p.sense)
+	case p.formulation == ConvexCombination:
+		p.addConvexCombination(model)
+	default:
+		p.addIncremental(model)
+	}
+
+	return nil
+}
+
+func (p *PiecewiseLinear) validate() error {
+	if len(p.breakpoints) < 2 {
+		return errors.New("highs: piecewise-linear function needs at least two breakpoints")
+	}
+	for k, point := range p.breakpoints {
+		if math.IsNaN(point.X) || math.IsNaN(point.Y) ||
+			math.IsInf(point.X, 0) || math.IsInf(point.Y, 0) {
+			return fmt.Errorf("highs: breakpoint %d (%v, %v) is not finite", k, point.X, point.Y)
+		}
+		if k > 0 && point.X <= p.breakpoints[k-1].X {
+			return fmt.Errorf(
+				"highs: breakpoint %d has X %v, want greater than %v",
+				k, point.X, p.breakpoints[k-1].X,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (p *PiecewiseLinear) slopes() []float64 {
+	points := p.breakpoints
+	slopes := make([]float64, len(points)-1)
+	for k := range slopes {
+		slopes[k] = (points[k+1].Y - points[k].Y) / (points[k+1].X - points[k].X)
+	}
+
+	return slopes
+}
+
+// addSegments adds y sense slope·x + intercept for every segment and
+// restricts x to the interval of the breakpoints.
+func (p *PiecewiseLinear) addSegments(model mip.Model, slopes []float64, sense mip.Sense) {
+	for k, slope := range slopes {
+		point := p.breakpoints[k]
+		c := model.NewConstraint(sense, point.Y-slope*point.X)
+		c.NewTerm(1, p.y)
+		c.NewTerm(-slope, p.x)
+		p.added = append(p.added, c)
+	}
+
+	first, last := p.breakpoints[0].X, p.breakpoints[len(p.breakpoints)-1].X
+	if p.x.LowerBound() < first {
+		c := model.NewConstraint(mip.GreaterThanOrEqual, first)
+		c.NewTerm(1, p.x)
+		p.added = append(p.added, c)
+	}
+	if p.x.UpperBound() > last {
+		c := model.NewConstraint(mip.LessThanOrEqual, last)
+		c.NewTerm(1, p.x)
+		p.added = append(p.added, c)
+	}
+}
+
+// addConvexCombination adds
+//
+//	x = Σ λ_i x_i, y sense Σ λ_i y_i, Σ λ_i = 1, Σ z_k = 1,
+//	λ_i <= z_{i-1} + z_i
+//
+// with λ_i in [0, 1] for every breakpoint and a binary z_k for the segment
+// between breakpoints k and k+1.
+func (p *PiecewiseLinear) addConvexCombination(model mip.Model) {
+	points := p.breakpoints
+	lambdas := make(mip.Vars, len(points))
+	for i := range lambdas {
+		lambdas[i] = p.newFloat(model)
+	}
+	segments := make(mip.Vars, len(points)-1)
+	for k := range segments {
+		segments[k] = p.newBool(model)
+	}
+
+	convexity := p.newConstraint(model, mip.Equal, 1)
+	x := p.newConstraint(model, mip.Equal, 0)
+	x.NewTerm(1, p.x)
+	y := p.newConstraint(model, p.sense, 0)
+	y.NewTerm(1, p.y)
+	for i, lambda := range lambdas {
+		convexity.NewTerm(1, lambda)
+		x.NewTerm(-points[i].X, lambda)
+		y.NewTerm(-points[i].Y, lambda)
+	}
+
+	segment := p.newConstraint(model, mip.Equal, 1)
+	for _, z := range segments {
+		segment.NewTerm(1, z)
+	}
+
+	for i, lambda := range lambdas {
+		adjacent := p.newConstraint(model, mip.LessThanOrEqual, 0)
+		adjacent.NewTerm(1, lambda)
+		if i > 0 {
+			adjacent.NewTerm(-1, segments[i-1])
+		}
+		if i < len(segments) {
+			adjacent.NewTerm(-1, segments[i])
+		}
+	}
+}
+
+// addIncremental adds
+//
+//	x = x_0 + Σ δ_k (x_k - x_{k-1}), y sense y_0 + Σ δ_k (y_k - y_{k-1}),
+//	δ_{k+1} <= w_k <= δ_k
+//
+// with δ_k in [0, 1] for every segment and a binary w_k between consecutive
+// segments, so a segment is only used once the previous one is full.
+func (p *PiecewiseLinear) addIncremental(model mip.Model) {
+	points := p.breakpoints
+	deltas := make(mip.Vars, len(points)-1)
+	for k := range deltas {
+		deltas[k] = p.newFloat(model)
+	}
+
+	x := p.newConstraint(model, mip.Equal, points[0].X)
+	x.NewTerm(1, p.x)
+	y := p.newConstraint(model, p.sense, points[0].Y)
+	y.NewTerm(1, p.y)
+	for k, delta := range deltas {
+		x.NewTerm(-(points[k+1].X - points[k].X), delta)
+		y.NewTerm(-(points[k+1].Y - points[k].Y), delta)
+	}
+
+	for k := 0; k+1 < len(deltas); k++ {
+		w := p.newBool(model)
+
+		next := p.newConstraint(model, mip.LessThanOrEqual, 0)
+		next.NewTerm(1, deltas[k+1])
+		next.NewTerm(-1, w)
+
+		previous := p.newConstraint(model, mip.LessThanOrEqual, 0)
+		previous.NewTerm(1, w)
+		previous.NewTerm(-1, deltas[k])
+	}
+}
+
+func (p *PiecewiseLinear) newFloat(model mip.Model) mip.Var {
+	v := model.NewFloat(0, 1)
+	p.vars = append(p.vars, v)
+
+	return v
+}
+
+func (p *PiecewiseLinear) newBool(model mip.Model) mip.Var {
+	v := model.NewBool()
+	p.vars = append(p.vars, v)
+	p.binaries = append(p.binaries, v)
+
+	return v
+}
+
+func (p *PiecewiseLinear) newConstraint(
+	model mip.Model,
+	sense mip.Sense,
+	rhs float64,
+) mip.Constraint {
+	c := model.NewConstraint(sense, rhs)
+	p.added = append(p.added, c)
+
+	return c
+}
+
+// isConvex reports whether the slopes are non-decreasing.
+func isConvex(slopes []float64) bool {
+	tolerance := slopeTolerance * maxAbs(slopes)
+	for k := 1; k < len(slopes); k++ {
+		if slopes[k] < slopes[k-1]-tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isLinear reports whether all slopes are equal.
+func isLinear(slopes []float64) bool {
+	tolerance := slopeTolerance * maxAbs(slopes)
+	for _, slope := range slopes {
+		if math.Abs(slope-slopes[0]) > tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func negate(values []float64) []float64 {
+	negated := make([]float64, len(values))
+	for i, value := range values {
+		negated[i] = -value
+	}
+
+	return negated
+}
+
+func maxAbs(values []float64) float64 {
+	largest := 0.0
+	for _, value := range values {
+		largest = math.Max(largest, math.Abs(value))
+	}
+
+	return largest
+}
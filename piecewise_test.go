@@ -0,0 +1,107 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestPiecewiseLinear(t *testing.T) {
+	// Tiered freight rates that get more expensive with volume.
+	convex := []highs.Breakpoint{{0, 0}, {10, 10}, {20, 30}, {30, 60}}
+	// Volume discounts.
+	concave := []highs.Breakpoint{{0, 0}, {10, 20}, {20, 30}, {30, 35}}
+
+	tests := []struct {
+		name        string
+		breakpoints []highs.Breakpoint
+		sense       mip.Sense
+		maximize    bool
+		binaries    bool
+		x           float64
+		y           float64
+	}{
+		// minimize y subject to y >= f(x), x >= 15
+		{name: "convex cost", breakpoints: convex, sense: mip.GreaterThanOrEqual, x: 15, y: 20},
+		{name: "concave cost", breakpoints: concave, sense: mip.GreaterThanOrEqual, binaries: true, x: 15, y: 25},
+		// maximize y subject to y <= f(x), x >= 15
+		{name: "concave revenue", breakpoints: concave, sense: mip.LessThanOrEqual, maximize: true, x: 30, y: 35},
+		// maximize y subject to y = f(x), x >= 15
+		{name: "equal", breakpoints: convex, sense: mip.Equal, maximize: true, binaries: true, x: 30, y: 60},
+		{name: "linear", breakpoints: []highs.Breakpoint{{0, 1}, {10, 21}, {20, 41}}, sense: mip.Equal, x: 15, y: 31},
+	}
+
+	for _, test := range tests {
+		for _, formulation := range []highs.PiecewiseFormulation{
+			highs.Incremental,
+			highs.ConvexCombination,
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				model := mip.NewModel()
+				x := model.NewFloat(15, 50)
+				y := model.NewFloat(-100, 100)
+				if test.maximize {
+					model.Objective().SetMaximize()
+				}
+				model.Objective().NewTerm(1, y)
+
+				f := highs.NewPiecewiseLinear(x, y, test.sense, test.breakpoints)
+				f.SetFormulation(formulation)
+				if err := f.AddTo(model); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := len(f.Binaries()) > 0; got != test.binaries {
+					t.Errorf("got binaries %v, want %v", got, test.binaries)
+				}
+
+				solution, err := highs.NewSolver(model).Solve(defaultOptions())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !solution.IsOptimal() {
+					t.Fatal("expected optimal solution")
+				}
+
+				if math.Abs(solution.Value(x)-test.x) > 1e-6 ||
+					math.Abs(solution.Value(y)-test.y) > 1e-6 {
+					t.Errorf("got (%v, %v), want (%v, %v)",
+						solution.Value(x), solution.Value(y), test.x, test.y)
+				}
+
+				value, err := f.Evaluate(test.x)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if math.Abs(value-test.y) > 1e-9 && test.sense == mip.Equal {
+					t.Errorf("got f(%v) = %v, want %v", test.x, value, test.y)
+				}
+			})
+		}
+	}
+}
+
+func TestPiecewiseLinearInvalidBreakpoints(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(0, 10)
+	y := model.NewFloat(0, 10)
+
+	for _, breakpoints := range [][]highs.Breakpoint{
+		{{0, 0}},
+		{{0, 0}, {0, 1}},
+		{{0, 0}, {math.Inf(1), 1}},
+	} {
+		f := highs.NewPiecewiseLinear(x, y, mip.Equal, breakpoints)
+		if err := f.AddTo(model); err == nil {
+			t.Errorf("want error for breakpoints %v", breakpoints)
+		}
+		if _, err := f.Evaluate(0); err == nil {
+			t.Errorf("want evaluate error for breakpoints %v", breakpoints)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// RangedConstraint is a constraint lower <= a·x <= upper. HiGHS supports
+// both bounds on a single row, so a ranged constraint is passed as one row
+// instead of the two constraints a [mip.Model] needs.
+type RangedConstraint struct {
+	name  string
+	terms mip.Terms
+	lower float64
+	upper float64
+}
+
+// NewRangedConstraint adds a ranged constraint lower <= a·x <= upper to the
+// model. Use ±math.MaxFloat64 or ±Inf for a missing bound. The activity and
+// dual of the constraint are reported by [Solution].
+func (m *Model) NewRangedConstraint(lower, upper float64) *RangedConstraint {
+	c := &RangedConstraint{
+		terms: make(mip.Terms, 0),
+		lower: lower,
+		upper: upper,
+	}
+	m.rangedConstraints = append(m.rangedConstraints, c)
+
+	return c
+}
+
+// RangedConstraints returns a copy slice of the ranged constraints of the
+// model.
+func (m *Model) RangedConstraints() []*RangedConstraint {
+	return append([]*RangedConstraint(nil), m.rangedConstraints...)
+}
+
+// NewTerm adds a term to the constraint. Invoking it multiple times for the
+// same variable sums the coefficients.
+func (c *RangedConstraint) NewTerm(coefficient float64, variable mip.Var) mip.Term {
+	if math.IsNaN(coefficient) {
+		panic("ranged constraint term coefficient is NaN")
+	}
+
+	t := term{coefficient: coefficient, variable: variable}
+	c.terms = append(c.terms, t)
+
+	return t
+}
+
+// Terms returns the terms of the constraint, with the coefficients of the
+// terms of the same variable summed and zero terms dropped.
+func (c *RangedConstraint) Terms() mip.Terms {
+	return uniqueTerms(c.terms)
+}
+
+// Lower returns the lower bound of the constraint.
+func (c *RangedConstraint) Lower() float64 {
+	return c.lower
+}
+
+// Upper returns the upper bound of the constraint.
+func (c *RangedConstraint) Upper() float64 {
+	return c.upper
+}
+
+// Name returns the name of the constraint.
+func (c *RangedConstraint) Name() string {
+	return c.name
+}
+
+// SetName sets the name of the constraint.
+func (c *RangedConstraint) SetName(name string) {
+	c.name = name
+}
+
+// copyTo returns a copy of the constraint whose terms refer to the given
+// variables of a copied model.
+func (c *RangedConstraint) copyTo(vars mip.Vars) *RangedConstraint {
+	terms := make(mip.Terms, len(c.terms))
+	for i, t := range c.terms {
+		terms[i] = term{
+			coefficient: t.Coefficient(),
+			variable:    vars[t.Var().Index()],
+		}
+	}
+
+	return &RangedConstraint{
+		name:  c.name,
+		terms: terms,
+		lower: c.lower,
+		upper: c.upper,
+	}
+}
+
+// rangedConstraints returns the ranged constraints of a *Model and nil for
+// other models.
+func rangedConstraints(model mip.Model) []*RangedConstraint {
+	if m, ok := model.(*Model); ok {
+		return m.rangedConstraints
+	}
+
+	return nil
+}
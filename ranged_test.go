@@ -0,0 +1,89 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+)
+
+func TestRangedConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		maximize bool
+		activity float64
+	}{
+		{name: "lower bound binding", maximize: false, activity: 2},
+		{name: "upper bound binding", maximize: true, activity: 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// min/max x + y subject to 2 <= x + y <= 5
+			model := highs.NewModel()
+			x := model.NewFloat(0, 10)
+			y := model.NewFloat(0, 10)
+			if test.maximize {
+				model.Objective().SetMaximize()
+			}
+			model.Objective().NewTerm(1, x)
+			model.Objective().NewTerm(1, y)
+
+			c := model.NewRangedConstraint(2, 5)
+			c.NewTerm(1, x)
+			c.NewTerm(1, y)
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			s := solution.(highs.Solution)
+			if math.Abs(s.RangedActivity(c)-test.activity) > 1e-6 {
+				t.Errorf("got activity %v, want %v", s.RangedActivity(c), test.activity)
+			}
+
+			if math.Abs(math.Abs(s.RangedDual(c))-1) > 1e-6 {
+				t.Errorf("got dual %v, want magnitude 1", s.RangedDual(c))
+			}
+
+			copied := model.Copy().(*highs.Model)
+			solution, err = highs.NewSolver(copied).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			activity := solution.(highs.Solution).RangedActivity(copied.RangedConstraints()[0])
+			if math.Abs(activity-test.activity) > 1e-6 {
+				t.Errorf("got activity %v of the copy, want %v", activity, test.activity)
+			}
+		})
+	}
+}
+
+func TestRangedConstraintInvertedBounds(t *testing.T) {
+	model := highs.NewModel()
+	x := model.NewFloat(0, 10)
+	c := model.NewRangedConstraint(5, 2)
+	c.SetName("inverted")
+	c.NewTerm(1, x)
+
+	_, err := highs.NewSolver(model).Solve(defaultOptions())
+
+	var validationError *highs.ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("want ValidationError, got %v", err)
+	}
+
+	issue := validationError.Issues[0]
+	if issue.Kind != highs.IssueInvertedBounds || issue.RangedConstraint != c {
+		t.Errorf("got issue %v, want inverted bounds of the ranged constraint", issue)
+	}
+}
@@ -0,0 +1,77 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// semiKind is the kind of a semi-continuous or semi-integer variable.
+type semiKind int
+
+const (
+	semiContinuous semiKind = iota + 1
+	semiInteger
+)
+
+// NewSemiContinuous adds a semi-continuous variable to the model. The
+// variable is either 0 or between lower and upper. The upper bound must be
+// finite.
+func (m *Model) NewSemiContinuous(lower, upper float64) mip.Float {
+	v := m.NewFloat(lower, upper)
+	m.setSemiKind(v, semiContinuous)
+
+	return v
+}
+
+// NewSemiInteger adds a semi-integer variable to the model. The variable is
+// either 0 or an integer between lower and upper. The upper bound must be
+// finite.
+func (m *Model) NewSemiInteger(lower, upper int64) mip.Int {
+	v := m.NewInt(lower, upper)
+	m.setSemiKind(v, semiInteger)
+
+	return v
+}
+
+// IsSemiContinuous reports whether the variable was created with
+// [Model.NewSemiContinuous].
+func (m *Model) IsSemiContinuous(v mip.Var) bool {
+	return m.semiVars[v.Index()] == semiContinuous
+}
+
+// IsSemiInteger reports whether the variable was created with
+// [Model.NewSemiInteger].
+func (m *Model) IsSemiInteger(v mip.Var) bool {
+	return m.semiVars[v.Index()] == semiInteger
+}
+
+func (m *Model) setSemiKind(v mip.Var, kind semiKind) {
+	if m.semiVars == nil {
+		m.semiVars = make(map[int]semiKind)
+	}
+	m.semiVars[v.Index()] = kind
+}
+
+// semiVars returns the kinds of the semi-continuous and semi-integer
+// variables of a *Model by variable index and nil for other models.
+func semiVars(model mip.Model) map[int]semiKind {
+	if m, ok := model.(*Model); ok {
+		return m.semiVars
+	}
+
+	return nil
+}
+
+// varBounds returns the bounds of the variable, widened to include 0 for
+// semi-continuous and semi-integer variables, which can also be 0.
+func varBounds(v mip.Var, semi map[int]semiKind) (float64, float64) {
+	lower, upper := v.LowerBound(), v.UpperBound()
+	if semi[v.Index()] != 0 {
+		return math.Min(0, lower), math.Max(0, upper)
+	}
+
+	return lower, upper
+}
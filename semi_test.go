@@ -0,0 +1,110 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestSemiContinuous(t *testing.T) {
+	tests := []struct {
+		name     string
+		sense    mip.Sense
+		rhs      float64
+		maximize bool
+		want     float64
+	}{
+		// x <= 2 only admits x = 0.
+		{name: "zero", sense: mip.LessThanOrEqual, rhs: 2, maximize: true, want: 0},
+		// x >= 1 only admits x in [3, 10].
+		{name: "lower bound", sense: mip.GreaterThanOrEqual, rhs: 1, want: 3},
+		{name: "upper bound", sense: mip.GreaterThanOrEqual, rhs: 1, maximize: true, want: 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := highs.NewModel()
+			x := model.NewSemiContinuous(3, 10)
+			if test.maximize {
+				model.Objective().SetMaximize()
+			}
+			model.Objective().NewTerm(1, x)
+			c := model.NewConstraint(test.sense, test.rhs)
+			c.NewTerm(1, x)
+
+			if !model.IsSemiContinuous(x) || model.IsSemiInteger(x) {
+				t.Fatal("expected semi-continuous variable")
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.Value(x)-test.want) > 1e-6 {
+				t.Errorf("got x = %v, want %v", solution.Value(x), test.want)
+			}
+		})
+	}
+}
+
+func TestSemiInteger(t *testing.T) {
+	tests := []struct {
+		name string
+		rhs  float64
+		want float64
+	}{
+		// 2x <= 9 admits x in {0, 2, 3, 4}.
+		{name: "integer", rhs: 9, want: 4},
+		// 2x <= 3 only admits x = 0.
+		{name: "zero", rhs: 3, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := highs.NewModel()
+			x := model.NewSemiInteger(2, 8)
+			model.Objective().SetMaximize()
+			model.Objective().NewTerm(1, x)
+			c := model.NewConstraint(mip.LessThanOrEqual, test.rhs)
+			c.NewTerm(2, x)
+
+			if !model.IsSemiInteger(x) || !model.Copy().(*highs.Model).IsSemiInteger(x) {
+				t.Fatal("expected semi-integer variable")
+			}
+
+			solution, err := highs.NewSolver(model).Solve(defaultOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !solution.IsOptimal() {
+				t.Fatal("expected optimal solution")
+			}
+
+			if math.Abs(solution.Value(x)-test.want) > 1e-6 {
+				t.Errorf("got x = %v, want %v", solution.Value(x), test.want)
+			}
+		})
+	}
+}
+
+func TestSemiContinuousInfiniteUpperBound(t *testing.T) {
+	model := highs.NewModel()
+	model.NewSemiContinuous(1, math.MaxFloat64)
+
+	var validationError *highs.ValidationError
+	if !errors.As(highs.Validate(model), &validationError) ||
+		validationError.Issues[0].Kind != highs.IssueInfiniteSemiBound {
+		t.Errorf("want infinite semi bound issue, got %v", validationError)
+	}
+}
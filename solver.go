@@ -6,14 +6,20 @@ package highs
 /*
    #cgo darwin,arm64 LDFLAGS: ${SRCDIR}/external/darwin-arm64/lib/libhighs.a -lc++
    #cgo darwin,arm64 CFLAGS: -I${SRCDIR}/external/darwin-arm64/include/highs -mmacosx-version-min=11.0
+   #cgo darwin,arm64 CXXFLAGS: -I${SRCDIR}/external/darwin-arm64/include/highs -mmacosx-version-min=11.0
    #cgo darwin,amd64 LDFLAGS: ${SRCDIR}/external/darwin-amd64/lib/libhighs.a -lc++
    #cgo darwin,amd64 CFLAGS: -I${SRCDIR}/external/darwin-amd64/include/highs -mmacosx-version-min=11.0
+   #cgo darwin,amd64 CXXFLAGS: -I${SRCDIR}/external/darwin-amd64/include/highs -mmacosx-version-min=11.0
    #cgo linux,amd64 LDFLAGS: ${SRCDIR}/external/linux-amd64/lib/libhighs.a -lstdc++ -lm -ldl -lz
    #cgo linux,amd64 CFLAGS: -I${SRCDIR}/external/linux-amd64/include/highs
+   #cgo linux,amd64 CXXFLAGS: -I${SRCDIR}/external/linux-amd64/include/highs
    #cgo linux,arm64 LDFLAGS: ${SRCDIR}/external/linux-arm64/lib/libhighs.a -lstdc++ -lm -ldl
    #cgo linux,arm64 CFLAGS: -I${SRCDIR}/external/linux-arm64/include/highs
+   #cgo linux,arm64 CXXFLAGS: -I${SRCDIR}/external/linux-arm64/include/highs
    #cgo CXXFLAGS: -std=c++11
    #include "interfaces/highs_c_api.h"
+   #include "highs.h"
+   #include "names.h"
    #include <stdlib.h>
 */
 import "C"
@@ -23,7 +29,6 @@ import (
 	"fmt"
 	"math"
 	"runtime"
-	"sort"
 	"time"
 	"unsafe"
 
@@ -35,16 +40,62 @@ import (
 
 // NewSolver creates solver using Highs as back-end solver.
 func NewSolver(model mip.Model) mip.Solver {
+	return NewSolverWithOptions(model, DefaultOptions())
+}
+
+// NewSolverWithOptions creates solver using Highs as back-end solver. The
+// duration and MIP gaps passed to Solve take precedence over the options
+// file, the typed options over both and the control options passed to Solve
+// over all of them.
+func NewSolverWithOptions(model mip.Model, options Options) mip.Solver {
 	return &solverHighs{
-		model: model,
+		model:   model,
+		options: options,
 	}
 }
 
 // Solve solves a given model with some options.
 func (solver *solverHighs) Solve(options mip.SolveOptions) (mip.Solution, error) {
 	start := time.Now()
+	fileOptions, err := validateOptions(options, solver.options)
+	if err != nil {
+		return nil, fmt.Errorf("error handling options in HiGHS solver: %w", err)
+	}
+
+	if err := Validate(solver.model); err != nil {
+		return nil, err
+	}
+
+	// Products of binaries are rewritten into a MILP, the quadratic
+	// objective does not need to be convex then.
+	linearization, isLinearized := linearizeBinaryProducts(solver.model)
+	if !isLinearized {
+		err := checkConvexity(
+			solver.model,
+			solver.options.convexityCheckLimit(),
+			start.Add(options.Duration),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	violated := violatedEmptyConstraints(
+		solver.model,
+		solver.options.primalFeasibilityTolerance(),
+	)
+	if len(violated) > 0 {
+		return &highsSolution{
+			violatedConstraints: violated,
+			runtime:             time.Since(start),
+			solutionStatus:      infeasible,
+		}, nil
+	}
+
 	if len(solver.model.Vars()) == 0 {
 		return &highsSolution{
+			objectiveValue: objectiveConstant(solver.model),
+			runtime:        time.Since(start),
 			solutionStatus: optimal,
 		}, nil
 	}
@@ -57,26 +108,136 @@ func (solver *solverHighs) Solve(options mip.SolveOptions) (mip.Solution, error)
 	}
 	defer C.Highs_destroy(highsPtr)
 
-	input := solver.newHighsInput(highsPtr, start)
+	input := solver.newHighsInput(highsPtr, start, linearization)
 
-	if err := handleOptions(highsPtr, *input, options); err != nil {
+	if err := handleOptions(highsPtr, *input, options, solver.options, fileOptions); err != nil {
 		return nil, fmt.Errorf("error handling options in HiGHS solver: %w", err)
 	}
 
-	isMiqp := solver.model.Objective().IsQuadratic() &&
-		input.isIntegerProblem
+	isMiqp := input.isQuadraticProblem && input.isIntegerProblem
 	if isMiqp {
-		return nil, errMiqpNotSupported
+		return branchAndBound(highsPtr, input, solver.model, options)
+	}
+
+	solution, err := solve(highsPtr, options, input)
+	if err != nil {
+		return solution, err
 	}
 
-	return solve(highsPtr, options, input)
+	if solver.options.WriteFile != "" {
+		if err := writeOptionsFile(highsPtr, solver.options.WriteFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return solution, nil
+}
+
+// Solution is the solution returned by the HiGHS solver. It extends
+// [mip.Solution] with information specific to HiGHS.
+type Solution interface {
+	mip.Solution
+	// OptionDeviations returns the HiGHS options that differed from their
+	// default during the solve.
+	OptionDeviations() []OptionDeviation
+	// ViolatedConstraints returns the constraints without terms whose
+	// right-hand side cannot be satisfied, such as 0 <= -5. They make the
+	// model infeasible and are never passed to HiGHS.
+	ViolatedConstraints() []mip.Constraint
+	// RangedActivity returns the value of a·x of a ranged constraint of the
+	// solved *Model.
+	RangedActivity(c *RangedConstraint) float64
+	// RangedDual returns the dual value HiGHS reports for the row of a
+	// ranged constraint of the solved *Model. It is 0 for MIPs.
+	RangedDual(c *RangedConstraint) float64
+	// Dual returns the dual value HiGHS reports for the row of a
+	// constraint. Reduced costs are c - A^T y for the duals y. It is 0 for
+	// MIPs and for constraints without terms, which are not passed to
+	// HiGHS, and math.MaxFloat64 for constraints that are not part of the
+	// solved model or if the solution has no values, like the other
+	// values of the solution.
+	Dual(c mip.Constraint) float64
+	// ReducedCost returns the reduced cost HiGHS reports for the column of
+	// a variable. It is 0 for MIPs.
+	ReducedCost(v mip.Var) float64
+	// ColumnValue returns the value of a column generated by a
+	// [ColumnGenerator]. It is math.MaxFloat64 for columns that are not
+	// part of the solved master.
+	ColumnValue(c *Column) float64
 }
 
 type highsSolution struct {
-	values         []float64
-	solutionStatus solutionStatus
-	objectiveValue float64
-	runtime        time.Duration
+	values              []float64
+	rowValues           []float64
+	rowDuals            []float64
+	reducedCosts        []float64
+	constraintRows      map[mip.Constraint]int
+	rangedRows          map[*RangedConstraint]int
+	columns             map[*Column]int
+	optionDeviations    []OptionDeviation
+	violatedConstraints []mip.Constraint
+	solutionStatus      solutionStatus
+	objectiveValue      float64
+	runtime             time.Duration
+	// suboptimal is true if there are values that are not proven optimal,
+	// such as the incumbent of a branch-and-bound or the last round of a
+	// lazy solve that ran out of time.
+	suboptimal bool
+}
+
+func (l *highsSolution) OptionDeviations() []OptionDeviation {
+	return l.optionDeviations
+}
+
+func (l *highsSolution) ViolatedConstraints() []mip.Constraint {
+	return l.violatedConstraints
+}
+
+func (l *highsSolution) RangedActivity(c *RangedConstraint) float64 {
+	row, ok := l.rangedRows[c]
+	if !ok || row >= len(l.rowValues) {
+		return math.MaxFloat64
+	}
+
+	return l.rowValues[row]
+}
+
+func (l *highsSolution) RangedDual(c *RangedConstraint) float64 {
+	row, ok := l.rangedRows[c]
+	if !ok || row >= len(l.rowDuals) {
+		return math.MaxFloat64
+	}
+
+	return l.rowDuals[row]
+}
+
+func (l *highsSolution) Dual(c mip.Constraint) float64 {
+	row, ok := l.constraintRows[c]
+	if ok && row < len(l.rowDuals) {
+		return l.rowDuals[row]
+	}
+	if !ok && l.HasValues() && len(linearTerms(c.Terms())) == 0 {
+		return 0
+	}
+
+	return math.MaxFloat64
+}
+
+func (l *highsSolution) ReducedCost(v mip.Var) float64 {
+	if v.Index() >= len(l.reducedCosts) {
+		return math.MaxFloat64
+	}
+
+	return l.reducedCosts[v.Index()]
+}
+
+func (l *highsSolution) ColumnValue(c *Column) float64 {
+	column, ok := l.columns[c]
+	if !ok || column >= len(l.values) {
+		return math.MaxFloat64
+	}
+
+	return l.values[column]
 }
 
 func (l *highsSolution) ObjectiveValue() float64 {
@@ -108,11 +269,11 @@ func (l *highsSolution) IsOptimal() bool {
 }
 
 func (l *highsSolution) HasValues() bool {
-	return hasValues(l.solutionStatus)
+	return hasValues(l.solutionStatus) || l.suboptimal
 }
 
 func (l *highsSolution) IsSubOptimal() bool {
-	return false
+	return l.suboptimal
 }
 
 func (l *highsSolution) IsTimeOut() bool {
@@ -130,7 +291,11 @@ func (l *highsSolution) IsInfeasible() bool {
 }
 
 type solverHighs struct {
-	model mip.Model
+	model   mip.Model
+	options Options
+	// keepEmptyConstraints passes constraints without terms as rows, for
+	// algorithms that add columns to them.
+	keepEmptyConstraints bool
 }
 
 type highsInput struct {
@@ -151,6 +316,16 @@ type highsInput struct {
 	numQuadraticNonZeros       int
 	numColumns                 int
 	numRows                    int
+	numModelColumns            int
+	numModelRows               int
+	linearization              *binaryLinearization
+	constraintRows             map[mip.Constraint]int
+	rangedRows                 map[*RangedConstraint]int
+	columnNames                []string
+	rowNames                   []string
+	modelFile                  string
+	offset                     C.double
+	infinity                   C.double
 	sense                      C.int
 	isIntegerProblem           bool
 	isQuadraticProblem         bool
@@ -175,6 +350,7 @@ func hasValues(solutionStatus solutionStatus) bool {
 func (solver *solverHighs) newHighsInput(
 	highsPtr unsafe.Pointer,
 	start time.Time,
+	linearization *binaryLinearization,
 ) *highsInput {
 	// infinity is defined as
 	// std::numeric_limits<double>::infinity()
@@ -183,25 +359,84 @@ func (solver *solverHighs) newHighsInput(
 
 	input := new(highsInput)
 	input.start = start
+	input.infinity = infinity
 
-	input.numColumns = len(solver.model.Vars())
+	input.numModelColumns = len(solver.model.Vars())
+	input.numColumns = input.numModelColumns
+	input.linearization = linearization
+	if linearization != nil {
+		input.numColumns += len(linearization.products)
+	}
 	allConstraints := solver.model.Constraints()
-	constraintsWithTerms := make(mip.Constraints, 0, len(allConstraints))
-	for _, c := range allConstraints {
-		if len(c.Terms()) > 0 {
-			constraintsWithTerms = append(constraintsWithTerms, c)
+	ranged := rangedConstraints(solver.model)
+	naming := modelNaming(solver.model)
+	names := newNameEscaper()
+
+	input.columnNames = make([]string, input.numColumns)
+	for _, v := range solver.model.Vars() {
+		input.columnNames[v.Index()] = names.escape(naming.varName(v))
+	}
+	if linearization != nil {
+		for k, p := range linearization.products {
+			input.columnNames[input.numModelColumns+k] = names.escape(
+				input.columnNames[p.i] + "_" + input.columnNames[p.j],
+			)
 		}
 	}
 
-	input.numRows = len(constraintsWithTerms)
+	rows := make([]highsRow, 0, len(allConstraints)+len(ranged))
+	input.rowNames = make([]string, 0, len(allConstraints)+len(ranged))
+	input.constraintRows = make(map[mip.Constraint]int, len(allConstraints))
+	for i, c := range allConstraints {
+		terms := linearTerms(c.Terms())
+		if len(terms) > 0 || solver.keepEmptyConstraints {
+			input.constraintRows[c] = len(rows)
+			lower, upper := senseBounds(c)
+			rows = append(rows, highsRow{terms: terms, lower: lower, upper: upper})
+			input.rowNames = append(
+				input.rowNames,
+				names.escape(naming.constraintName(c, i)),
+			)
+		}
+	}
 
-	prepareColumns(input, solver, constraintsWithTerms, infinity)
+	// Ranged constraints are always passed, HiGHS reports them as
+	// infeasible if they are empty and violated.
+	input.rangedRows = make(map[*RangedConstraint]int, len(ranged))
+	for i, c := range ranged {
+		input.rangedRows[c] = len(rows)
+		rows = append(rows, highsRow{
+			terms: linearTerms(c.Terms()),
+			lower: c.Lower(),
+			upper: c.Upper(),
+		})
+		input.rowNames = append(
+			input.rowNames,
+			names.escape(naming.rangedConstraintName(c, i)),
+		)
+	}
+	input.numModelRows = len(rows)
+	if linearization != nil {
+		for k, row := range linearization.rows(input.numModelColumns) {
+			rows = append(rows, row)
+			input.rowNames = append(
+				input.rowNames,
+				names.escape(input.columnNames[input.numModelColumns+k/3]+mccormickSuffixes[k%3]),
+			)
+		}
+	}
+	input.modelFile = solver.options.WriteModel
+
+	input.numRows = len(rows)
+
+	prepareColumns(input, solver, rows, infinity)
 
-	prepareConstraintMatrix(input, solver, constraintsWithTerms, infinity)
+	prepareConstraintMatrix(input, solver, rows, infinity)
 
 	prepareHessian(input, solver)
 	input.isQuadraticProblem = input.numQuadraticNonZeros > 0
 
+	input.offset = C.double(objectiveConstant(solver.model))
 	input.sense = C.kHighsObjSenseMinimize
 
 	if solver.model.Objective().IsMaximize() {
@@ -210,7 +445,46 @@ func (solver *solverHighs) newHighsInput(
 	return input
 }
 
-func mapVarTypeToIntegrality(variable mip.Var) C.int {
+// isEmptyConstraint reports whether a constraint has no term with a non-zero
+// coefficient, for example because its terms cancel out.
+func isEmptyConstraint(c mip.Constraint) bool {
+	return len(linearTerms(c.Terms())) == 0
+}
+
+// violatedEmptyConstraints returns the empty constraints of the model whose
+// right-hand side is violated by more than the tolerance.
+func violatedEmptyConstraints(model mip.Model, tolerance float64) []mip.Constraint {
+	violated := make([]mip.Constraint, 0)
+	for _, c := range model.Constraints() {
+		if !isEmptyConstraint(c) {
+			continue
+		}
+		rhs := c.RightHandSide()
+		var ok bool
+		switch c.Sense() {
+		case mip.LessThanOrEqual:
+			ok = rhs >= -tolerance
+		case mip.GreaterThanOrEqual:
+			ok = rhs <= tolerance
+		case mip.Equal:
+			ok = math.Abs(rhs) <= tolerance
+		}
+		if !ok {
+			violated = append(violated, c)
+		}
+	}
+
+	return violated
+}
+
+func mapVarTypeToIntegrality(variable mip.Var, semiVars map[int]semiKind) C.int {
+	switch semiVars[variable.Index()] {
+	case semiContinuous:
+		return C.kHighsVarTypeSemiContinuous
+	case semiInteger:
+		return C.kHighsVarTypeSemiInteger
+	}
+
 	if variable.IsBool() || variable.IsInt() {
 		return C.kHighsVarTypeInteger
 	}
@@ -218,15 +492,35 @@ func mapVarTypeToIntegrality(variable mip.Var) C.int {
 	return C.kHighsVarTypeContinuous
 }
 
-func handleOptions(highsPtr unsafe.Pointer, input highsInput, options mip.SolveOptions) error {
+// handleOptions passes the options to HiGHS. Later options take precedence
+// over earlier ones: the options of the options file come first, followed
+// by the output flag, duration and MIP gaps of the solve options, the typed
+// options and the control options. A file written by HiGHS contains the
+// time limit and gaps of its solve, the solve options replace them.
+func handleOptions(
+	highsPtr unsafe.Pointer,
+	input highsInput,
+	options mip.SolveOptions,
+	highsOptions Options,
+	fileOptions []optionValue,
+) error {
+	for _, option := range fileOptions {
+		if err := setOptionValue(highsPtr, option); err != nil {
+			return err
+		}
+	}
+
 	if err := setOutputFlag(highsPtr, options); err != nil {
 		return err
 	}
 
+	// The time spent before the solve, such as checking convexity, counts
+	// towards the duration.
+	remaining := options.Duration - time.Since(input.start)
 	if err := setDoubleOption(
 		highsPtr,
 		"time_limit",
-		options.Duration.Seconds(),
+		math.Max(remaining.Seconds(), 0),
 	); err != nil {
 		return err
 	}
@@ -249,6 +543,10 @@ func handleOptions(highsPtr unsafe.Pointer, input highsInput, options mip.SolveO
 		}
 	}
 
+	if err := setOptions(highsPtr, highsOptions); err != nil {
+		return err
+	}
+
 	controlOptions, err := options.Control.ToTyped()
 	if err != nil {
 		return err
@@ -366,6 +664,18 @@ func setDoubleOption(highsPtr unsafe.Pointer, option string, value float64) erro
 	return nil
 }
 
+func getDoubleOption(highsPtr unsafe.Pointer, option string) (float64, error) {
+	optionName := C.CString(option)
+	defer C.free(unsafe.Pointer(optionName))
+	var value C.double
+	status := C.Highs_getDoubleOptionValue(highsPtr, optionName, &value)
+	if status != C.kHighsStatusOk {
+		return 0, fmt.Errorf("HiGHS failed getting float (double) option %s", option)
+	}
+
+	return float64(value), nil
+}
+
 func setIntOption(highsPtr unsafe.Pointer, option string, value int) error {
 	optionName := C.CString(option)
 	defer C.free(unsafe.Pointer(optionName))
@@ -393,6 +703,46 @@ func setStringOption(highsPtr unsafe.Pointer, option string, value string) error
 func solve(
 	highsPtr unsafe.Pointer, _ mip.SolveOptions, input *highsInput,
 ) (*highsSolution, error) {
+	if err := passModel(highsPtr, input); err != nil {
+		return &highsSolution{
+			solutionStatus: statusUnknown,
+		}, err
+	}
+
+	if input.modelFile != "" {
+		if err := writeModel(highsPtr, input.modelFile); err != nil {
+			return nil, err
+		}
+	}
+
+	solution, err := runModel(highsPtr, input)
+	if err != nil {
+		return solution, err
+	}
+	solution.optionDeviations = readOptionDeviations(highsPtr)
+
+	// The columns and rows of linearized binary products are not part of
+	// the model.
+	solution.trim(input)
+
+	return solution, nil
+}
+
+// trim restricts the values and duals of the solution to the columns and
+// rows of the model, dropping those added for the solve.
+func (l *highsSolution) trim(input *highsInput) {
+	if l.values == nil {
+		return
+	}
+
+	l.values = l.values[:input.numModelColumns]
+	l.reducedCosts = l.reducedCosts[:input.numModelColumns]
+	l.rowValues = l.rowValues[:input.numModelRows]
+	l.rowDuals = l.rowDuals[:input.numModelRows]
+}
+
+// passModel passes the model and its names to HiGHS.
+func passModel(highsPtr unsafe.Pointer, input *highsInput) error {
 	pRowLowerBound := (*C.double)(unsafe.Pointer(nil))
 	pRowUpperBound := (*C.double)(unsafe.Pointer(nil))
 	pColumnIntegrality := (*C.int)(unsafe.Pointer(nil))
@@ -447,16 +797,19 @@ func solve(
 		))
 	}
 
-	status := C.Highs_passModel(
+	columnNames := toCStrings(input.columnNames)
+	defer freeCStrings(columnNames)
+	rowNames := toCStrings(input.rowNames)
+	defer freeCStrings(rowNames)
+
+	status := C.Highs_passModelWithNames(
 		highsPtr,
 		C.int(input.numColumns),
 		C.int(input.numRows),
 		C.int(input.numNonZeros),
 		C.int(input.numQuadraticNonZeros),
-		C.kHighsMatrixFormatRowwise,
-		C.kHighsHessianFormatTriangular,
 		input.sense,
-		C.double(0.0),
+		input.offset,
 		pColumnCosts,
 		pColumnLowerBound,
 		pColumnUpperBound,
@@ -469,14 +822,20 @@ func solve(
 		pHessianConstraintMatrixIndices,
 		pHessianConstraintMatrixValues,
 		pColumnIntegrality,
+		firstCString(columnNames),
+		firstCString(rowNames),
 	)
 
 	if status != C.kHighsStatusOk {
-		return &highsSolution{
-			solutionStatus: statusUnknown,
-		}, errPassing
+		return errPassing
 	}
 
+	return nil
+}
+
+// runModel runs HiGHS on the model passed to it and returns the solution
+// for all columns and rows of the input.
+func runModel(highsPtr unsafe.Pointer, input *highsInput) (*highsSolution, error) {
 	runStatus := C.Highs_run(highsPtr)
 
 	if !(runStatus == C.kHighsStatusOk || runStatus == C.kHighsStatusWarning) {
@@ -487,10 +846,10 @@ func solve(
 	modelStatus := C.Highs_getModelStatus(highsPtr)
 
 	columnValues := make([]float64, input.numColumns)
-	columnDuals := make([]C.double, input.numColumns)
+	columnDuals := make([]float64, input.numColumns)
 
 	rowValues := make([]float64, input.numRows)
-	rowDuals := make([]C.double, input.numRows)
+	rowDuals := make([]float64, input.numRows)
 
 	pRowValues := (*C.double)(unsafe.Pointer(nil))
 	pRowDuals := (*C.double)(unsafe.Pointer(nil))
@@ -500,7 +859,7 @@ func solve(
 		pRowDuals = (*C.double)(unsafe.Pointer(&rowDuals[0]))
 	}
 
-	status = C.Highs_getSolution(
+	status := C.Highs_getSolution(
 		highsPtr,
 		(*C.double)(unsafe.Pointer(&columnValues[0])),
 		(*C.double)(unsafe.Pointer(&columnDuals[0])),
@@ -517,6 +876,11 @@ func solve(
 	runtime.KeepAlive(input)
 	return &highsSolution{
 		objectiveValue: objectiveValue,
+		constraintRows: input.constraintRows,
+		rangedRows:     input.rangedRows,
+		reducedCosts:   columnDuals,
+		rowDuals:       rowDuals,
+		rowValues:      rowValues,
 		runtime:        time.Since(input.start),
 		solutionStatus: solutionStatus(modelStatus),
 		values:         columnValues,
@@ -530,20 +894,17 @@ var (
 	errGetSolution = errors.New(
 		"highs failed getting the solution",
 	)
-	errMiqpNotSupported = errors.New(
-		"highs does not support mixed integer quadratic programs",
-	)
 )
 
 func prepareColumns(
 	input *highsInput,
 	solver *solverHighs,
-	constraintsWithTerms mip.Constraints,
-	_ C.double,
+	rows []highsRow,
+	infinity C.double,
 ) {
 	input.numNonZeros = 0
-	for _, c := range constraintsWithTerms {
-		input.numNonZeros += len(c.Terms())
+	for _, row := range rows {
+		input.numNonZeros += len(row.terms)
 	}
 
 	input.columnCosts = make([]C.double, input.numColumns)
@@ -553,27 +914,54 @@ func prepareColumns(
 
 	input.isIntegerProblem = false
 
+	semi := semiVars(solver.model)
 	for _, v := range solver.model.Vars() {
 		i := v.Index()
 		input.columnCosts[i] = C.double(0.0)
-		input.columnLowerBound[i] = C.double(v.LowerBound())
-		input.columnUpperBound[i] = C.double(v.UpperBound())
-		t := mapVarTypeToIntegrality(v)
+		input.columnLowerBound[i] = toHighsValue(v.LowerBound(), infinity)
+		input.columnUpperBound[i] = toHighsValue(v.UpperBound(), infinity)
+		t := mapVarTypeToIntegrality(v, semi)
 		input.columnIntegrality[i] = t
-		if t == C.kHighsVarTypeInteger {
+		if t != C.kHighsVarTypeContinuous {
 			input.isIntegerProblem = true
 		}
 	}
 
-	for _, term := range solver.model.Objective().Terms() {
-		input.columnCosts[term.Var().Index()] = C.double(term.Coefficient())
+	for _, term := range linearTerms(solver.model.Objective().Terms()) {
+		input.columnCosts[term.index] = C.double(term.coefficient)
+	}
+
+	if input.linearization != nil {
+		for i, cost := range input.linearization.costs {
+			input.columnCosts[i] += C.double(cost)
+		}
+		for k, p := range input.linearization.products {
+			i := input.numModelColumns + k
+			input.columnCosts[i] = C.double(p.coefficient)
+			input.columnLowerBound[i] = 0
+			input.columnUpperBound[i] = 1
+			input.columnIntegrality[i] = C.kHighsVarTypeContinuous
+		}
+	}
+}
+
+// toHighsValue converts a bound or right-hand side to HiGHS, mapping
+// ±math.MaxFloat64 and ±Inf to HiGHS infinity.
+func toHighsValue(value float64, infinity C.double) C.double {
+	if isInfinite(value) {
+		if value > 0 {
+			return infinity
+		}
+		return -infinity
 	}
+
+	return C.double(value)
 }
 
 func prepareConstraintMatrix(
 	input *highsInput,
 	_ *solverHighs,
-	constraintsWithTerms mip.Constraints,
+	rows []highsRow,
 	infinity C.double,
 ) {
 	input.rowConstraintMatrixBegins = make([]C.int, input.numRows)
@@ -585,27 +973,17 @@ func prepareConstraintMatrix(
 
 	rowConstraintMatrixBegin := 0
 
-	for i, c := range constraintsWithTerms {
+	for i, row := range rows {
 		input.rowConstraintMatrixBegins[i] = C.int(
 			rowConstraintMatrixBegin,
 		)
-		rhs := C.double(c.RightHandSide())
-		switch c.Sense() {
-		case mip.LessThanOrEqual:
-			input.rowLowerBound[i] = -infinity
-			input.rowUpperBound[i] = rhs
-		case mip.Equal:
-			input.rowLowerBound[i] = rhs
-			input.rowUpperBound[i] = rhs
-		case mip.GreaterThanOrEqual:
-			input.rowLowerBound[i] = rhs
-			input.rowUpperBound[i] = infinity
-		}
+		input.rowLowerBound[i] = toHighsValue(row.lower, infinity)
+		input.rowUpperBound[i] = toHighsValue(row.upper, infinity)
 
-		for _, t := range c.Terms() {
-			i := C.int(t.Var().Index())
+		for _, t := range row.terms {
+			i := C.int(t.index)
 			input.rowConstraintMatrixIndices[rowConstraintMatrixBegin] = i
-			c := C.double(t.Coefficient())
+			c := C.double(t.coefficient)
 			input.rowConstraintMatrixValues[rowConstraintMatrixBegin] = c
 
 			rowConstraintMatrixBegin++
@@ -614,54 +992,28 @@ func prepareConstraintMatrix(
 }
 
 func prepareHessian(input *highsInput, solver *solverHighs) {
-	qTerms := solver.model.Objective().QuadraticTerms()
-	qMat := make(map[int]map[int]mip.QuadraticTerm)
-	for _, v := range qTerms {
-		_, ok := qMat[v.Var1().Index()]
-		if !ok {
-			qMat[v.Var1().Index()] = make(map[int]mip.QuadraticTerm)
-		}
-		qMat[v.Var1().Index()][v.Var2().Index()] = v
-	}
-	input.numQuadraticNonZeros = len(qTerms)
-	input.hessianMatrixBegins = make([]C.int, 0, input.numColumns+1)
-	input.hessianMatrixIndices = make([]C.int, 0, input.numQuadraticNonZeros)
-	input.hessianMatrixValues = make([]C.double, 0, input.numQuadraticNonZeros)
-	nonZeros := 0
-	for i := 0; i < input.numColumns; i++ {
-		input.hessianMatrixBegins = append(input.hessianMatrixBegins, C.int(nonZeros))
-		row, ok := qMat[i]
-		if !ok {
-			continue
-		}
-		terms := make(mip.QuadraticTerms, 0, len(row))
-		for _, v := range row {
-			terms = append(terms, v)
-		}
-		sort.SliceStable(terms, func(i, j int) bool {
-			return terms[i].Var2().Index() < terms[j].Var2().Index()
-		})
-		for _, v := range terms {
-			input.hessianMatrixIndices = append(
-				input.hessianMatrixIndices,
-				C.int(v.Var2().Index()),
-			)
-			coef := v.Coefficient()
-			if v.Var1().Index() == v.Var2().Index() {
-				coef *= 2.0
-			}
-			// highs solves the problem of min/max c^tx * 1/2*x^tQx
-			// however it is more intuitive (we assume) when developers
-			// can expect min/max c^tx * x^tQx.
-			input.hessianMatrixValues = append(
-				input.hessianMatrixValues,
-				C.double(coef),
-			)
-		}
-		nonZeros += len(terms)
+	if input.linearization != nil {
+		input.numQuadraticNonZeros = 0
+		return
+	}
+
+	// highs solves the problem of min/max c^tx * 1/2*x^tQx
+	// however it is more intuitive (we assume) when developers
+	// can expect min/max c^tx * x^tQx, the builder scales the
+	// entries accordingly.
+	builder := newHessianBuilder(input.numColumns)
+	builder.addTerms(solver.model.Objective().QuadraticTerms())
+	begins, indices, values := builder.build()
+
+	input.numQuadraticNonZeros = len(values)
+	input.hessianMatrixBegins = make([]C.int, len(begins))
+	input.hessianMatrixIndices = make([]C.int, len(indices))
+	input.hessianMatrixValues = make([]C.double, len(values))
+	for i, begin := range begins {
+		input.hessianMatrixBegins[i] = C.int(begin)
+	}
+	for i, index := range indices {
+		input.hessianMatrixIndices[i] = C.int(index)
+		input.hessianMatrixValues[i] = C.double(values[i])
 	}
-	input.hessianMatrixBegins = append(
-		input.hessianMatrixBegins,
-		C.int(input.numQuadraticNonZeros),
-	)
 }
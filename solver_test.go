@@ -24,8 +24,7 @@ func TestHighs(t *testing.T) {
 func TestHighsMIQP(t *testing.T) {
 	// minimize x_1^2
 	//
-	// subject to x integer
-	// should error for HiGHS
+	// subject to x integer, x >= 4
 	m := mip.NewModel()
 	x1 := m.NewInt(4, math.MaxInt64)
 
@@ -33,10 +32,21 @@ func TestHighsMIQP(t *testing.T) {
 	obj.SetMinimize()
 	obj.NewQuadraticTerm(1.0, x1, x1)
 	solver := highs.NewSolver(m)
-	opt := mip.SolveOptions{}
-	_, err := solver.Solve(opt)
-	if err == nil {
-		t.Error("Want error, got nil")
+	solution, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if math.Abs(solution.Value(x1)-4) > 1e-6 {
+		t.Errorf("got x1 = %v, want 4", solution.Value(x1))
+	}
+
+	if math.Abs(solution.ObjectiveValue()-16) > 1e-6 {
+		t.Errorf("got objective %v, want 16", solution.ObjectiveValue())
 	}
 }
 
@@ -44,7 +54,6 @@ func TestHighsMIQP2(t *testing.T) {
 	// minimize x_2^2
 	//
 	// subject to x1 <= x2, x2 integer
-	// should error for HiGHS
 	m := mip.NewModel()
 	x1 := m.NewFloat(4, math.MaxFloat64)
 	x2 := m.NewInt(4, math.MaxInt64)
@@ -56,10 +65,65 @@ func TestHighsMIQP2(t *testing.T) {
 	cstr.NewTerm(1, x1)
 	cstr.NewTerm(-1, x2)
 	solver := highs.NewSolver(m)
-	opt := mip.SolveOptions{}
-	_, err := solver.Solve(opt)
-	if err == nil {
-		t.Error("Want error, got nil")
+	solution, err := solver.Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if solution.Value(x2) != 4 {
+		t.Errorf("got x2 = %v, want 4", solution.Value(x2))
+	}
+
+	if math.Abs(solution.ObjectiveValue()-16) > 1e-6 {
+		t.Errorf("got objective %v, want 16", solution.ObjectiveValue())
+	}
+}
+
+func TestHighsMIQPOffset(t *testing.T) {
+	// minimize (x_2 - 4.3)^2 = x_2^2 - 8.6 x_2 + 18.49
+	//
+	// subject to x1 <= x2, x1 >= 2.5, x2 integer
+	m := highs.NewModel()
+	x1 := m.NewFloat(2.5, math.MaxFloat64)
+	x2 := m.NewInt(0, 10)
+
+	obj := m.Objective()
+	obj.SetMinimize()
+	obj.NewQuadraticTerm(1.0, x2, x2)
+	obj.NewTerm(-8.6, x2)
+	m.AddObjectiveConstant(18.49)
+	cstr := m.NewConstraint(mip.LessThanOrEqual, 0)
+	cstr.NewTerm(1, x1)
+	cstr.NewTerm(-1, x2)
+	solution, err := highs.NewSolver(m).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if solution.Value(x2) != 4 {
+		t.Errorf("got x2 = %v, want 4", solution.Value(x2))
+	}
+
+	if math.Abs(solution.ObjectiveValue()-0.09) > 1e-6 {
+		t.Errorf("got objective %v, want 0.09", solution.ObjectiveValue())
+	}
+}
+
+func TestHighsMIQPSemi(t *testing.T) {
+	m := highs.NewModel()
+	x := m.NewSemiInteger(2, 5)
+	m.Objective().NewQuadraticTerm(1.0, x, x)
+
+	if _, err := highs.NewSolver(m).Solve(defaultOptions()); err == nil {
+		t.Error("want error, got nil")
 	}
 }
 
@@ -0,0 +1,99 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"math"
+	"sort"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// term implements [mip.Term].
+type term struct {
+	coefficient float64
+	variable    mip.Var
+}
+
+func (t term) Coefficient() float64 {
+	return t.coefficient
+}
+
+func (t term) Var() mip.Var {
+	return t.variable
+}
+
+// linearTerm is a term of a row or of the objective in the form passed to
+// HiGHS.
+type linearTerm struct {
+	index       int
+	coefficient float64
+}
+
+// highsRow is a row passed to HiGHS, lower <= terms <= upper.
+type highsRow struct {
+	terms []linearTerm
+	lower float64
+	upper float64
+}
+
+// senseBounds returns the row bounds of a constraint.
+func senseBounds(c mip.Constraint) (lower, upper float64) {
+	rhs := c.RightHandSide()
+	switch c.Sense() {
+	case mip.LessThanOrEqual:
+		return math.Inf(-1), rhs
+	case mip.GreaterThanOrEqual:
+		return rhs, math.Inf(1)
+	default:
+		return rhs, rhs
+	}
+}
+
+// uniqueTerms sums the coefficients of the terms of the same variable and
+// drops the terms whose coefficient is zero, as the terms of go-mip
+// constraints and objectives are. The terms keep the order in which their
+// variables first appear.
+func uniqueTerms(terms mip.Terms) mip.Terms {
+	positions := make(map[int]int, len(terms))
+	summed := make([]term, 0, len(terms))
+	for _, t := range terms {
+		index := t.Var().Index()
+		if p, ok := positions[index]; ok {
+			summed[p].coefficient += t.Coefficient()
+			continue
+		}
+		positions[index] = len(summed)
+		summed = append(summed, term{coefficient: t.Coefficient(), variable: t.Var()})
+	}
+
+	unique := make(mip.Terms, 0, len(summed))
+	for _, t := range summed {
+		if t.coefficient != 0 {
+			unique = append(unique, t)
+		}
+	}
+
+	return unique
+}
+
+// linearTerms returns the terms in the form passed to HiGHS, in the order
+// of the variable index, dropping the terms whose coefficient is zero. The
+// terms must not repeat a variable, which holds for the terms of go-mip
+// models and of the constraints and objectives of this package.
+func linearTerms(terms mip.Terms) []linearTerm {
+	converted := make([]linearTerm, 0, len(terms))
+	for _, t := range terms {
+		if t.Coefficient() != 0 {
+			converted = append(converted, linearTerm{
+				index:       t.Var().Index(),
+				coefficient: t.Coefficient(),
+			})
+		}
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return converted[i].index < converted[j].index
+	})
+
+	return converted
+}
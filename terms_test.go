@@ -0,0 +1,108 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+type linearTerm struct {
+	coefficient float64
+	variable    mip.Var
+}
+
+func (t linearTerm) Coefficient() float64 { return t.coefficient }
+func (t linearTerm) Var() mip.Var         { return t.variable }
+
+// rawTermsConstraint returns its terms as added, without dropping zero
+// terms.
+type rawTermsConstraint struct {
+	mip.Constraint
+	terms mip.Terms
+}
+
+func (c *rawTermsConstraint) Terms() mip.Terms { return c.terms }
+
+// rawTermsObjective returns its terms as added, without dropping zero terms.
+type rawTermsObjective struct {
+	mip.Objective
+	terms mip.Terms
+}
+
+func (o *rawTermsObjective) Terms() mip.Terms { return o.terms }
+
+type rawTermsModel struct {
+	mip.Model
+	objective   *rawTermsObjective
+	constraints mip.Constraints
+}
+
+func (m *rawTermsModel) Objective() mip.Objective     { return m.objective }
+func (m *rawTermsModel) Constraints() mip.Constraints { return m.constraints }
+
+func TestZeroTerms(t *testing.T) {
+	// maximize 2x + y
+	// subject to x + y <= 4, with a zero term of y passed before its
+	// non-zero term
+	model := mip.NewModel()
+	x := model.NewFloat(0, 3)
+	y := model.NewFloat(0, 10)
+	model.Objective().SetMaximize()
+
+	c := model.NewConstraint(mip.LessThanOrEqual, 4)
+
+	raw := &rawTermsModel{
+		Model: model,
+		objective: &rawTermsObjective{
+			Objective: model.Objective(),
+			terms: mip.Terms{
+				linearTerm{2, x},
+				linearTerm{0, y},
+				linearTerm{1, y},
+			},
+		},
+		constraints: mip.Constraints{
+			&rawTermsConstraint{
+				Constraint: c,
+				terms: mip.Terms{
+					linearTerm{1, x},
+					linearTerm{0, y},
+					linearTerm{1, y},
+				},
+			},
+		},
+	}
+
+	solution, err := highs.NewSolver(raw).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if math.Abs(solution.ObjectiveValue()-7) > 1e-6 {
+		t.Errorf("got objective %v, want 7", solution.ObjectiveValue())
+	}
+
+	// 0y >= 1 is empty once the zero term is dropped.
+	d := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	raw.constraints = append(raw.constraints, &rawTermsConstraint{
+		Constraint: d,
+		terms:      mip.Terms{linearTerm{0, y}},
+	})
+
+	solution, err = highs.NewSolver(raw).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsInfeasible() {
+		t.Error("expected infeasible solution for 0 >= 1")
+	}
+}
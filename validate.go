@@ -0,0 +1,236 @@
+// © 2019-present nextmv.io inc
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/nextmv-io/go-mip"
+)
+
+// hugeValue is the magnitude from which HiGHS treats bounds and costs as
+// infinite, the default of the infinite_bound and infinite_cost options.
+// The package treats such values as infinite as well.
+const hugeValue = 1e20
+
+// IssueKind classifies a problem found by [Validate].
+type IssueKind string
+
+// Kinds of model issues.
+const (
+	// IssueNaN is a NaN bound, coefficient or right-hand side.
+	IssueNaN IssueKind = "nan"
+	// IssueInfiniteCoefficient is an infinite objective or constraint
+	// coefficient.
+	IssueInfiniteCoefficient IssueKind = "infinite_coefficient"
+	// IssueInfiniteRightHandSide is an infinite right-hand side that makes
+	// a constraint infeasible.
+	IssueInfiniteRightHandSide IssueKind = "infinite_right_hand_side"
+	// IssueInvertedBounds is a variable whose lower bound exceeds its upper
+	// bound.
+	IssueInvertedBounds IssueKind = "inverted_bounds"
+	// IssueFractionalBound is an integer variable with a fractional bound.
+	IssueFractionalBound IssueKind = "fractional_bound"
+	// IssueInfiniteSemiBound is a semi-continuous or semi-integer variable
+	// without a finite upper bound.
+	IssueInfiniteSemiBound IssueKind = "infinite_semi_bound"
+)
+
+// Issue is a single problem of a model.
+type Issue struct {
+	// Kind of the problem.
+	Kind IssueKind
+	// Var is the offending variable. It is nil for issues of constraints.
+	Var mip.Var
+	// Constraint is the offending constraint. It is nil for issues of
+	// variables, the objective and ranged constraints.
+	Constraint mip.Constraint
+	// RangedConstraint is the offending ranged constraint of a *Model.
+	RangedConstraint *RangedConstraint
+	// ConstraintIndex is the index of Constraint in the slice returned by
+	// [mip.Model.Constraints] or of RangedConstraint in the slice returned
+	// by [Model.RangedConstraints], -1 if both are nil.
+	ConstraintIndex int
+	// Message describes the problem.
+	Message string
+}
+
+func (i Issue) String() string {
+	var sb strings.Builder
+	switch {
+	case i.Constraint != nil:
+		fmt.Fprintf(&sb, "constraint %d", i.ConstraintIndex)
+		if name := i.Constraint.Name(); name != "" {
+			fmt.Fprintf(&sb, " (%s)", name)
+		}
+	case i.RangedConstraint != nil:
+		fmt.Fprintf(&sb, "ranged constraint %d", i.ConstraintIndex)
+		if name := i.RangedConstraint.Name(); name != "" {
+			fmt.Fprintf(&sb, " (%s)", name)
+		}
+	}
+	if i.Var != nil && (i.Constraint != nil || i.RangedConstraint != nil) {
+		sb.WriteString(", ")
+	}
+	if i.Var != nil {
+		fmt.Fprintf(&sb, "variable %d", i.Var.Index())
+		if name := i.Var.Name(); name != "" {
+			fmt.Fprintf(&sb, " (%s)", name)
+		}
+	}
+	if i.Var == nil && i.Constraint == nil && i.RangedConstraint == nil {
+		sb.WriteString("objective")
+	}
+	fmt.Fprintf(&sb, ": %s", i.Message)
+
+	return sb.String()
+}
+
+// ValidationError is returned when a model holds values HiGHS cannot
+// handle. It lists every issue found.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	issues := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		issues[i] = issue.String()
+	}
+
+	return fmt.Sprintf(
+		"highs found %d issue(s) in the model: %s",
+		len(e.Issues),
+		strings.Join(issues, "; "),
+	)
+}
+
+// Validate checks that a model can be passed to HiGHS. It returns a
+// *ValidationError listing every NaN value, infinite coefficient or objective
+// constant and inverted or fractional integer bound. Bounds and right-hand
+// sides of ±math.MaxFloat64, ±Inf or a magnitude of at least 1e20 are valid
+// and passed to HiGHS as infinity.
+func Validate(model mip.Model) error {
+	issues := make([]Issue, 0)
+	add := func(kind IssueKind, v mip.Var, c mip.Constraint, index int, format string, args ...any) {
+		issues = append(issues, Issue{
+			Kind:            kind,
+			Var:             v,
+			Constraint:      c,
+			ConstraintIndex: index,
+			Message:         fmt.Sprintf(format, args...),
+		})
+	}
+
+	semi := semiVars(model)
+	for _, v := range model.Vars() {
+		lb, ub := v.LowerBound(), v.UpperBound()
+		for _, bound := range []struct {
+			name  string
+			value float64
+		}{{"lower", lb}, {"upper", ub}} {
+			switch {
+			case math.IsNaN(bound.value):
+				add(IssueNaN, v, nil, -1, "%s bound is NaN", bound.name)
+			case (v.IsInt() || v.IsBool()) && !isInfinite(bound.value) &&
+				bound.value != math.Trunc(bound.value):
+				add(IssueFractionalBound, v, nil, -1,
+					"integer variable has fractional %s bound %v", bound.name, bound.value)
+			}
+		}
+		if lb > ub {
+			add(IssueInvertedBounds, v, nil, -1,
+				"lower bound %v exceeds upper bound %v", lb, ub)
+		}
+		if _, ok := semi[v.Index()]; ok && isInfinite(ub) {
+			add(IssueInfiniteSemiBound, v, nil, -1,
+				"semi-continuous or semi-integer variable has infinite upper bound")
+		}
+	}
+
+	checkCoefficient(objectiveConstant(model), func(kind IssueKind, msg string) {
+		add(kind, nil, nil, -1, "constant %s", msg)
+	})
+
+	for _, t := range model.Objective().Terms() {
+		checkCoefficient(t.Coefficient(), func(kind IssueKind, msg string) {
+			add(kind, t.Var(), nil, -1, "objective coefficient %s", msg)
+		})
+	}
+
+	for _, t := range model.Objective().QuadraticTerms() {
+		checkCoefficient(t.Coefficient(), func(kind IssueKind, msg string) {
+			add(kind, t.Var1(), nil, -1, "quadratic objective coefficient with variable %d %s",
+				t.Var2().Index(), msg)
+		})
+	}
+
+	for i, c := range model.Constraints() {
+		rhs := c.RightHandSide()
+		switch {
+		case math.IsNaN(rhs):
+			add(IssueNaN, nil, c, i, "right-hand side is NaN")
+		case isInfinite(rhs) &&
+			!(c.Sense() == mip.LessThanOrEqual && rhs > 0) &&
+			!(c.Sense() == mip.GreaterThanOrEqual && rhs < 0):
+			add(IssueInfiniteRightHandSide, nil, c, i,
+				"infinite right-hand side %v makes the constraint infeasible", rhs)
+		}
+		for _, t := range c.Terms() {
+			checkCoefficient(t.Coefficient(), func(kind IssueKind, msg string) {
+				add(kind, t.Var(), c, i, "coefficient %s", msg)
+			})
+		}
+	}
+
+	for i, c := range rangedConstraints(model) {
+		addRanged := func(kind IssueKind, v mip.Var, format string, args ...any) {
+			issues = append(issues, Issue{
+				Kind:             kind,
+				Var:              v,
+				RangedConstraint: c,
+				ConstraintIndex:  i,
+				Message:          fmt.Sprintf(format, args...),
+			})
+		}
+		lower, upper := c.Lower(), c.Upper()
+		switch {
+		case math.IsNaN(lower) || math.IsNaN(upper):
+			addRanged(IssueNaN, nil, "bound is NaN")
+		case isInfinite(lower) && lower > 0, isInfinite(upper) && upper < 0:
+			addRanged(IssueInfiniteRightHandSide, nil,
+				"infinite bounds [%v, %v] make the constraint infeasible", lower, upper)
+		case lower > upper:
+			addRanged(IssueInvertedBounds, nil,
+				"lower bound %v exceeds upper bound %v", lower, upper)
+		}
+		for _, t := range c.Terms() {
+			checkCoefficient(t.Coefficient(), func(kind IssueKind, msg string) {
+				addRanged(kind, t.Var(), "coefficient %s", msg)
+			})
+		}
+	}
+
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+
+	return nil
+}
+
+func checkCoefficient(coefficient float64, report func(IssueKind, string)) {
+	switch {
+	case math.IsNaN(coefficient):
+		report(IssueNaN, "is NaN")
+	case isInfinite(coefficient):
+		report(IssueInfiniteCoefficient, fmt.Sprintf("%v is infinite", coefficient))
+	}
+}
+
+// isInfinite reports whether value is ±Inf, ±math.MaxFloat64 or so large
+// that HiGHS treats it as infinite, which the package treats as infinity.
+func isInfinite(value float64) bool {
+	return math.Abs(value) >= hugeValue
+}
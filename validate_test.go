@@ -0,0 +1,105 @@
+// © 2019-present nextmv.io inc
+
+package highs_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/nextmv-io/go-highs"
+	"github.com/nextmv-io/go-mip"
+)
+
+func TestValidateIssues(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(5, 1)
+	x.SetName("x")
+	model.NewFloat(0, 1e25)
+	z := model.NewFloat(0, 10)
+
+	model.Objective().NewTerm(math.Inf(1), z)
+
+	ok := model.NewConstraint(mip.LessThanOrEqual, math.MaxFloat64)
+	ok.NewTerm(1, z)
+
+	equal := model.NewConstraint(mip.Equal, math.Inf(1))
+	equal.SetName("equal")
+	equal.NewTerm(1, z)
+
+	coefficient := model.NewConstraint(mip.GreaterThanOrEqual, 1)
+	coefficient.NewTerm(-math.MaxFloat64, x)
+
+	_, err := highs.NewSolver(model).Solve(defaultOptions())
+
+	var validationError *highs.ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("want ValidationError, got %v", err)
+	}
+
+	type key struct {
+		kind       highs.IssueKind
+		variable   int
+		constraint int
+	}
+	want := map[key]bool{
+		{highs.IssueInvertedBounds, 0, -1}:        false,
+		{highs.IssueInfiniteCoefficient, 2, -1}:   false,
+		{highs.IssueInfiniteRightHandSide, -1, 1}: false,
+		{highs.IssueInfiniteCoefficient, 0, 2}:    false,
+	}
+
+	for _, issue := range validationError.Issues {
+		k := key{issue.Kind, -1, issue.ConstraintIndex}
+		if issue.Var != nil {
+			k.variable = issue.Var.Index()
+		}
+		if _, ok := want[k]; !ok {
+			t.Errorf("unexpected issue %v", issue)
+			continue
+		}
+		want[k] = true
+	}
+
+	for k, found := range want {
+		if !found {
+			t.Errorf("missing issue %+v in %v", k, err)
+		}
+	}
+}
+
+func TestValidateInfiniteBounds(t *testing.T) {
+	model := mip.NewModel()
+	x := model.NewFloat(-math.MaxFloat64, math.MaxFloat64)
+	y := model.NewFloat(math.Inf(-1), 3)
+	z := model.NewFloat(-1e25, 1e20)
+
+	c := model.NewConstraint(mip.GreaterThanOrEqual, -math.MaxFloat64)
+	c.NewTerm(1, x)
+	d := model.NewConstraint(mip.LessThanOrEqual, 4)
+	d.NewTerm(1, x)
+	d.NewTerm(1, y)
+	e := model.NewConstraint(mip.LessThanOrEqual, 1e30)
+	e.NewTerm(1, z)
+
+	model.Objective().SetMaximize()
+	model.Objective().NewTerm(1, x)
+	model.Objective().NewTerm(1, y)
+
+	if err := highs.Validate(model); err != nil {
+		t.Fatal(err)
+	}
+
+	solution, err := highs.NewSolver(model).Solve(defaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !solution.IsOptimal() {
+		t.Fatal("expected optimal solution")
+	}
+
+	if math.Abs(solution.ObjectiveValue()-4) > 1e-6 {
+		t.Errorf("got %v, want 4", solution.ObjectiveValue())
+	}
+}